@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+type PhaseSamples struct {
+	durations []time.Duration
+	allocs    []uint64
+	bytes     []uint64
+}
+
+func (p *PhaseSamples) add(duration time.Duration, allocs uint64, bytes uint64) {
+	p.durations = append(p.durations, duration)
+	p.allocs = append(p.allocs, allocs)
+	p.bytes = append(p.bytes, bytes)
+}
+
+type PhaseStats struct {
+	min         time.Duration
+	median      time.Duration
+	p95         time.Duration
+	max         time.Duration
+	allocsPerOp uint64
+	bytesPerOp  uint64
+}
+
+// getPercentile uses the nearest-rank method on already sorted durations.
+func getPercentile(sortedDurations []time.Duration, percentile float64) time.Duration {
+	rank := int(math.Ceil(percentile*float64(len(sortedDurations)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sortedDurations) {
+		rank = len(sortedDurations) - 1
+	}
+	return sortedDurations[rank]
+}
+
+func getPhaseStats(samples *PhaseSamples) PhaseStats {
+	if len(samples.durations) == 0 {
+		return PhaseStats{}
+	}
+
+	sortedDurations := append([]time.Duration(nil), samples.durations...)
+	sort.Slice(sortedDurations, func(i, j int) bool {
+		return sortedDurations[i] < sortedDurations[j]
+	})
+
+	var totalAllocs, totalBytes uint64
+	for idx := range samples.allocs {
+		totalAllocs += samples.allocs[idx]
+		totalBytes += samples.bytes[idx]
+	}
+	count := uint64(len(samples.allocs))
+
+	return PhaseStats{
+		min:         sortedDurations[0],
+		median:      getPercentile(sortedDurations, 0.5),
+		p95:         getPercentile(sortedDurations, 0.95),
+		max:         sortedDurations[len(sortedDurations)-1],
+		allocsPerOp: totalAllocs / count,
+		bytesPerOp:  totalBytes / count,
+	}
+}
+
+// measure reads the memory statistics outside of the timed section, so the
+// stop-the-world pause of ReadMemStats is not part of the duration.
+func measure(f func() error) (time.Duration, uint64, uint64, error) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	err := f()
+	duration := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return duration, after.Mallocs - before.Mallocs, after.TotalAlloc - before.TotalAlloc, err
+}
+
+// BenchmarkPhase is the timing of one phase of a solver.
+type BenchmarkPhase struct {
+	name  string
+	stats PhaseStats
+}
+
+// benchmarkSolver parses and solves the input once per iteration, since
+// several solvers modify their parsed input while solving. The streaming
+// solvers read their input while solving, so they are measured as a single
+// "streamed" phase instead of an empty parse and a solve that does the
+// parsing.
+func benchmarkSolver(key SolverKey, source InputSource, iterations int, warmup int) ([]BenchmarkPhase, error) {
+	solver, ok := registry[key]
+	if !ok {
+		return nil, fmt.Errorf("no solver for %v", key)
+	}
+	_, streamed := solver.(streamSolver)
+
+	data, err := readInput(source, key.day)
+	if err != nil {
+		return nil, err
+	}
+
+	parseSamples := &PhaseSamples{}
+	solveSamples := &PhaseSamples{}
+	for iteration := 0; iteration < warmup+iterations; iteration++ {
+		if streamed {
+			duration, allocs, allocated, err := measure(func() error {
+				input, err := solver.Parse(bytes.NewReader(data))
+				if err != nil {
+					return err
+				}
+				_, err = solver.Solve(input)
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			if iteration >= warmup {
+				solveSamples.add(duration, allocs, allocated)
+			}
+			continue
+		}
+
+		var input any
+		parseDuration, parseAllocs, parseBytes, err := measure(func() error {
+			var err error
+			input, err = solver.Parse(bytes.NewReader(data))
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		solveDuration, solveAllocs, solveBytes, err := measure(func() error {
+			_, err := solver.Solve(input)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if iteration < warmup {
+			continue
+		}
+		parseSamples.add(parseDuration, parseAllocs, parseBytes)
+		solveSamples.add(solveDuration, solveAllocs, solveBytes)
+	}
+
+	if streamed {
+		return []BenchmarkPhase{{"streamed", getPhaseStats(solveSamples)}}, nil
+	}
+	return []BenchmarkPhase{{"parse", getPhaseStats(parseSamples)}, {"solve", getPhaseStats(solveSamples)}}, nil
+}
+
+func printPhaseStats(writer *tabwriter.Writer, key SolverKey, phase string, stats PhaseStats) {
+	fmt.Fprintf(writer, "%d\t%d\t%s\t%v\t%v\t%v\t%v\t%d\t%d\t\n",
+		key.day, key.part, phase, stats.min, stats.median, stats.p95, stats.max, stats.allocsPerOp, stats.bytesPerOp)
+}
+
+func benchCommand(args []string) {
+	flagSet := flag.NewFlagSet("bench", flag.ExitOnError)
+	selection := addSelectionFlags(flagSet)
+	iterations := flagSet.Int("n", 20, "measured iterations per part")
+	warmup := flagSet.Int("warmup", 3, "unmeasured warmup iterations per part")
+	input := addInputFlag(flagSet)
+	flagSet.Parse(args)
+
+	if *iterations < 1 || *warmup < 0 {
+		log.Fatal("-n must be at least 1 and -warmup can not be negative")
+	}
+	if *selection.day == "" {
+		*selection.all = true
+	}
+	selected, err := selection.selectSolvers()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source := newInputSource(*input, len(selected))
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "day\tpart\tphase\tmin\tmedian\tp95\tmax\tallocs/op\tB/op\t")
+	for _, key := range selected {
+		phases, err := benchmarkSolver(key, source, *iterations, *warmup)
+		if err != nil {
+			fmt.Fprintf(writer, "%d\t%d\terror: %v\n", key.day, key.part, err)
+			continue
+		}
+		for _, phase := range phases {
+			printPhaseStats(writer, key, phase.name, phase.stats)
+		}
+	}
+	writer.Flush()
+}
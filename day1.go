@@ -1,67 +1,332 @@
 package main
 
 import (
-	"fmt"
-	"log"
+	"container/heap"
+	"math/rand"
 	"sort"
 	"strconv"
 )
 
-func day1_part1() {
-	rows, err := getRowsFromFile("input1.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	solution := 0
+// forEachElfsCalories streams the blank row separated groups of calories and
+// calls visit with the total of every elf, elves are counted from 1.
+func forEachElfsCalories(lines *LineScanner, visit func(elf ElfCalories)) error {
+	elf := 0
 	current_elfs_calories := 0
-	for _, row := range rows {
+	items := 0
+	for lines.Scan() {
+		row := lines.Text()
 		if row == "" {
-			if current_elfs_calories > solution {
-				solution = current_elfs_calories
+			if items > 0 {
+				elf++
+				visit(ElfCalories{elf: elf, items: items, calories: current_elfs_calories})
 			}
 			current_elfs_calories = 0
-		} else {
-			current_elfs_row_calories, err := strconv.Atoi(row)
-			if err != nil {
-				log.Fatal(err)
-			}
-			current_elfs_calories += current_elfs_row_calories
+			items = 0
+			continue
+		}
+
+		current_elfs_row_calories, err := strconv.Atoi(row)
+		if err != nil {
+			return newParseError(lines.Line(), 1, row, row, "a number of calories")
 		}
+		current_elfs_calories += current_elfs_row_calories
+		items++
+	}
+	if err := lines.Err(); err != nil {
+		return err
+	}
+
+	if items > 0 {
+		elf++
+		visit(ElfCalories{elf: elf, items: items, calories: current_elfs_calories})
+	}
+	return nil
+}
+
+// ElfCalories is what an elf carries, elf is its 1-based position in the
+// list.
+type ElfCalories struct {
+	elf      int
+	items    int
+	calories int
+}
+
+// isRankedBefore orders by calories, the elf earlier in the list wins a tie.
+func (e ElfCalories) isRankedBefore(other ElfCalories) bool {
+	if e.calories != other.calories {
+		return e.calories > other.calories
+	}
+	return e.elf < other.elf
+}
+
+// CaloriesHeap is a min heap with the lowest ranked elf on top.
+type CaloriesHeap []ElfCalories
+
+func (h CaloriesHeap) Len() int           { return len(h) }
+func (h CaloriesHeap) Less(i, j int) bool { return h[j].isRankedBefore(h[i]) }
+func (h CaloriesHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *CaloriesHeap) Push(x any) {
+	*h = append(*h, x.(ElfCalories))
+}
+
+func (h *CaloriesHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// TopElves keeps the n elves carrying the most calories, it never holds more
+// than n of them.
+type TopElves struct {
+	n    int
+	heap CaloriesHeap
+}
+
+func newTopElves(n int) *TopElves {
+	return &TopElves{n: n, heap: make(CaloriesHeap, 0, n)}
+}
+
+func (t *TopElves) add(elf ElfCalories) {
+	if t.n <= 0 {
+		return
+	}
+	if len(t.heap) < t.n {
+		heap.Push(&t.heap, elf)
+	} else if elf.isRankedBefore(t.heap[0]) {
+		t.heap[0] = elf
+		heap.Fix(&t.heap, 0)
+	}
+}
+
+// ranked returns the elves, the one carrying the most first.
+func (t *TopElves) ranked() []ElfCalories {
+	elves := append([]ElfCalories{}, t.heap...)
+	sort.Slice(elves, func(i, j int) bool {
+		return elves[i].isRankedBefore(elves[j])
+	})
+	return elves
+}
+
+// getTopElves streams the list and returns the n elves carrying the most
+// calories, ranked.
+func getTopElves(lines *LineScanner, n int) ([]ElfCalories, error) {
+	topElves := newTopElves(n)
+	if err := forEachElfsCalories(lines, topElves.add); err != nil {
+		return nil, err
 	}
+	return topElves.ranked(), nil
+}
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+func sumCalories(elves []ElfCalories) int {
+	sum := 0
+	for _, elf := range elves {
+		sum += elf.calories
+	}
+	return sum
 }
 
-func day1_part2() {
-	rows, err := getRowsFromFile("input1.txt")
+func day1_part1(lines *LineScanner) (Answer, error) {
+	topElves, err := getTopElves(lines, 1)
 	if err != nil {
-		log.Fatal(err)
+		return Answer{}, err
 	}
 
-	elfs_calories := make([]int, 0)
-	current_elfs_calories := 0
-	for _, row := range rows {
-		if row == "" {
-			elfs_calories = append(elfs_calories, current_elfs_calories)
-			current_elfs_calories = 0
-		} else {
-			current_elfs_row_calories, err := strconv.Atoi(row)
-			if err != nil {
-				log.Fatal(err)
-			}
-			current_elfs_calories += current_elfs_row_calories
-		}
+	return newIntAnswer(sumCalories(topElves)), nil
+}
+
+func day1_part2(lines *LineScanner) (Answer, error) {
+	topElves, err := getTopElves(lines, 3)
+	if err != nil {
+		return Answer{}, err
 	}
-	sort.Slice(elfs_calories, func(i, j int) bool {
-		return elfs_calories[i] > elfs_calories[j]
+
+	return newIntAnswer(sumCalories(topElves)), nil
+}
+
+func lintDay1(input *PuzzleInput) []error {
+	return lintRows(input.Lines(), func(line int, row string) error {
+		if _, err := strconv.Atoi(row); row != "" && err != nil {
+			return newParseError(line, 1, row, row, "a number of calories")
+		}
+		return nil
 	})
+}
+
+// RankingNode is a node of the treap behind ElfRanking, ordered by
+// isRankedBefore and a heap on priority. size and calories cover the whole
+// subtree.
+type RankingNode struct {
+	elf      ElfCalories
+	priority uint32
+	size     int
+	calories int
+	left     *RankingNode
+	right    *RankingNode
+}
+
+func (n *RankingNode) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *RankingNode) getCalories() int {
+	if n == nil {
+		return 0
+	}
+	return n.calories
+}
+
+func (n *RankingNode) update() {
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+	n.calories = n.elf.calories + n.left.getCalories() + n.right.getCalories()
+}
+
+// splitRankingNodes splits the subtree into the elves ranked before elf and
+// the rest.
+func splitRankingNodes(node *RankingNode, elf ElfCalories) (*RankingNode, *RankingNode) {
+	if node == nil {
+		return nil, nil
+	}
+	if node.elf.isRankedBefore(elf) {
+		before, rest := splitRankingNodes(node.right, elf)
+		node.right = before
+		node.update()
+		return node, rest
+	}
+	before, rest := splitRankingNodes(node.left, elf)
+	node.left = rest
+	node.update()
+	return before, node
+}
+
+// mergeRankingNodes joins two subtrees, every elf of before has to be ranked
+// before the elves of after.
+func mergeRankingNodes(before *RankingNode, after *RankingNode) *RankingNode {
+	if before == nil {
+		return after
+	}
+	if after == nil {
+		return before
+	}
+	if before.priority > after.priority {
+		before.right = mergeRankingNodes(before.right, after)
+		before.update()
+		return before
+	}
+	after.left = mergeRankingNodes(before, after.left)
+	after.update()
+	return after
+}
+
+func removeRankingNode(node *RankingNode, elf ElfCalories) *RankingNode {
+	if node == nil {
+		return nil
+	}
+	switch {
+	case node.elf == elf:
+		return mergeRankingNodes(node.left, node.right)
+	case node.elf.isRankedBefore(elf):
+		node.right = removeRankingNode(node.right, elf)
+	default:
+		node.left = removeRankingNode(node.left, elf)
+	}
+	node.update()
+	return node
+}
+
+// ElfRanking keeps the elves ordered by calories while they change, adding
+// an item, removing an elf, a rank and the sum of the top k all take
+// logarithmic time. Elves keep their number when others are removed.
+type ElfRanking struct {
+	root  *RankingNode
+	elves map[int]ElfCalories
+	rng   *rand.Rand
+}
+
+func newElfRanking() *ElfRanking {
+	return &ElfRanking{elves: map[int]ElfCalories{}, rng: rand.New(rand.NewSource(1))}
+}
+
+// getElfRanking streams the list into a ranking.
+func getElfRanking(lines *LineScanner) (*ElfRanking, error) {
+	ranking := newElfRanking()
+	if err := forEachElfsCalories(lines, ranking.insert); err != nil {
+		return nil, err
+	}
+	return ranking, nil
+}
+
+func (r *ElfRanking) len() int {
+	return r.root.getSize()
+}
+
+func (r *ElfRanking) insert(elf ElfCalories) {
+	r.removeElf(elf.elf)
+	r.elves[elf.elf] = elf
+	before, rest := splitRankingNodes(r.root, elf)
+	node := &RankingNode{elf: elf, priority: r.rng.Uint32()}
+	node.update()
+	r.root = mergeRankingNodes(mergeRankingNodes(before, node), rest)
+}
+
+// addItem gives the elf one more item, an elf not in the ranking yet starts
+// with just that item.
+func (r *ElfRanking) addItem(elf int, calories int) {
+	current := ElfCalories{elf: elf}
+	if existing, ok := r.elves[elf]; ok {
+		current = existing
+	}
+	current.items++
+	current.calories += calories
+	r.insert(current)
+}
 
-	solution := 0
-	for _, value := range elfs_calories[0:3] {
-		solution += value
+func (r *ElfRanking) removeElf(elf int) bool {
+	existing, ok := r.elves[elf]
+	if !ok {
+		return false
 	}
+	r.root = removeRankingNode(r.root, existing)
+	delete(r.elves, elf)
+	return true
+}
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+// rank returns the 1-based rank of the elf, 1 being the elf carrying the most
+// calories.
+func (r *ElfRanking) rank(elf int) (int, bool) {
+	existing, ok := r.elves[elf]
+	if !ok {
+		return 0, false
+	}
+	rank := 1
+	node := r.root
+	for node.elf != existing {
+		if node.elf.isRankedBefore(existing) {
+			rank += node.left.getSize() + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return rank + node.left.getSize(), true
 }
 
+// sumTop returns the calories carried by the k elves ranked first, or by all
+// of them when there are fewer than k.
+func (r *ElfRanking) sumTop(k int) int {
+	sum := 0
+	for node := r.root; node != nil && k > 0; {
+		if k <= node.left.getSize() {
+			node = node.left
+			continue
+		}
+		sum += node.left.getCalories() + node.elf.calories
+		k -= node.left.getSize() + 1
+		node = node.right
+	}
+	return sum
+}
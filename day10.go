@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 )
@@ -32,25 +31,38 @@ type Instruction struct {
 	value     int
 }
 
-func getInstructionFromString(instructionString string) Instruction {
+func getInstructionFromString(line int, instructionString string) (Instruction, error) {
 	parsedString := strings.Split(instructionString, " ")
-	if parsedString[0] == "noop" {
-		return Instruction{NOOP, 0}
-	} else {
+	if parsedString[0] == "noop" && len(parsedString) == 1 {
+		return Instruction{NOOP, 0}, nil
+	} else if parsedString[0] == "addx" && len(parsedString) == 2 {
 		value, err := strconv.Atoi(parsedString[1])
 		if err != nil {
-			log.Fatal(err)
+			return Instruction{}, newFieldParseError(line, instructionString, parsedString, 1, "a number")
 		}
-		return Instruction{ADDX, value}
+		return Instruction{ADDX, value}, nil
+	} else {
+		return Instruction{}, newParseError(line, 1, instructionString, instructionString, "\"noop\" or \"addx N\"")
+	}
+}
+
+func (i Instruction) String() string {
+	if i.operation == NOOP {
+		return "noop"
 	}
+	return fmt.Sprintf("addx %d", i.value)
 }
 
-func getInstructionsFromStringArray(instructionStrings []string) *[]Instruction {
+func getInstructionsFromStringArray(instructionStrings []string) (*[]Instruction, error) {
 	instructions := make([]Instruction, len(instructionStrings))
 	for idx, instructionString := range instructionStrings {
-		instructions[idx] = getInstructionFromString(instructionString)
+		instruction, err := getInstructionFromString(idx+1, instructionString)
+		if err != nil {
+			return nil, err
+		}
+		instructions[idx] = instruction
 	}
-	return &instructions
+	return &instructions, nil
 }
 
 func getSignalStrength(cycle int, xRegister int) int {
@@ -61,46 +73,55 @@ func getSignalStrength(cycle int, xRegister int) int {
 	}
 }
 
-func getSumOfSignalStrength(instructions *[]Instruction) int {
-	sumOfSignalStrength := 0
-	xRegister := 1
-	cycle := 0
-	idx := 0
+// CPU executes the instructions one cycle at the time.
+type CPU struct {
+	cycle     int
+	xRegister int
+}
 
-	for {
-		if idx >= len(*instructions) {
-			break
-		}
+func newCPU() *CPU {
+	return &CPU{cycle: 0, xRegister: 1}
+}
 
-		instruction := (*instructions)[idx]
-		if instruction.operation == NOOP {
-			cycle++
-			sumOfSignalStrength += getSignalStrength(cycle, xRegister)
-		} else {
-			cycle++
-			sumOfSignalStrength += getSignalStrength(cycle, xRegister)
-
-			cycle++
-			sumOfSignalStrength += getSignalStrength(cycle, xRegister)
-			xRegister += instruction.value
-		}
+// execute calls during for every cycle the instruction takes, with the value
+// the x register has during that cycle.
+func (c *CPU) execute(instruction Instruction, during func(cycle int, xRegister int)) {
+	if instruction.operation == NOOP {
+		c.cycle++
+		during(c.cycle, c.xRegister)
+	} else {
+		c.cycle++
+		during(c.cycle, c.xRegister)
 
-		idx++
+		c.cycle++
+		during(c.cycle, c.xRegister)
+		c.xRegister += instruction.value
 	}
-
-	return sumOfSignalStrength
 }
 
-func day10_part1() {
-	rows, err := getRowsFromFile("input10.txt")
+func forEachInstruction(lines *LineScanner, visit func(instruction Instruction)) error {
+	for lines.Scan() {
+		instruction, err := getInstructionFromString(lines.Line(), lines.Text())
+		if err != nil {
+			return err
+		}
+		visit(instruction)
+	}
+	return lines.Err()
+}
 
+func day10_part1(lines *LineScanner) (Answer, error) {
+	solution := 0
+	cpu := newCPU()
+	err := forEachInstruction(lines, func(instruction Instruction) {
+		cpu.execute(instruction, func(cycle int, xRegister int) {
+			solution += getSignalStrength(cycle, xRegister)
+		})
+	})
 	if err != nil {
-		log.Fatal(err)
+		return Answer{}, err
 	}
-
-	instructions := getInstructionsFromStringArray(rows[:len(rows)-1])
-	solution := getSumOfSignalStrength(instructions)
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return newIntAnswer(solution), nil
 }
 
 func printPixels(pixels *[][]byte) {
@@ -109,7 +130,12 @@ func printPixels(pixels *[][]byte) {
 	}
 }
 
-func drawPixelsFromInstructions(instructions *[]Instruction) *[][]byte {
+// CRT draws one pixel per cycle, lit when the sprite at the x register covers it.
+type CRT struct {
+	pixels [][]byte
+}
+
+func newCRT() *CRT {
 	pixels := make([][]byte, 6)
 	for idx := range pixels {
 		pixels[idx] = make([]byte, 40)
@@ -117,62 +143,37 @@ func drawPixelsFromInstructions(instructions *[]Instruction) *[][]byte {
 			pixels[idx][jdx] = '.'
 		}
 	}
+	return &CRT{pixels: pixels}
+}
 
-	pixelIdx := 0
-	pixelJdx := 0
-
-	xRegister := 1
-	cycle := 0
-	idx := 0
-
-	drawPixels := func() {
-		if xRegister-1 <= pixelJdx && pixelJdx <= xRegister+1 {
-			pixels[pixelIdx][pixelJdx] = '#'
-		}
-	}
-
-	cycleTick := func() {
-		cycle++
-		pixelJdx++
-		if pixelJdx%40 == 0 {
-			pixelIdx++
-			pixelJdx = 0
-		}
+func (c *CRT) drawPixel(cycle int, xRegister int) {
+	pixelIdx := (cycle - 1) / 40
+	pixelJdx := (cycle - 1) % 40
+	if pixelIdx >= len(c.pixels) {
+		return
 	}
 
-	for {
-		if idx >= len(*instructions) {
-			break
-		}
-
-		instruction := (*instructions)[idx]
-		if instruction.operation == NOOP {
-			drawPixels()
-			cycleTick()
-		} else {
-			drawPixels()
-			cycleTick()
-
-			drawPixels()
-			cycleTick()
-			xRegister += instruction.value
-
-		}
-
-		idx++
+	if xRegister-1 <= pixelJdx && pixelJdx <= xRegister+1 {
+		c.pixels[pixelIdx][pixelJdx] = '#'
 	}
-
-	return &pixels
 }
 
-func day10_part2() {
-	rows, err := getRowsFromFile("input10.txt")
-
+func day10_part2(lines *LineScanner) (Answer, error) {
+	crt := newCRT()
+	cpu := newCPU()
+	err := forEachInstruction(lines, func(instruction Instruction) {
+		cpu.execute(instruction, crt.drawPixel)
+	})
 	if err != nil {
-		log.Fatal(err)
+		return Answer{}, err
 	}
+	// printPixels(&crt.pixels)
+	return newImageAnswer(crt.pixels), nil
+}
 
-	instructions := getInstructionsFromStringArray(rows[:len(rows)-1])
-	pixels := drawPixelsFromInstructions(instructions)
-	printPixels(pixels)
+func lintDay10(input *PuzzleInput) []error {
+	return lintRows(input.Lines(), func(line int, row string) error {
+		_, err := getInstructionFromString(line, row)
+		return err
+	})
 }
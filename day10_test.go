@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func FuzzGetInstructionFromString(f *testing.F) {
+	for _, seed := range []string{"noop", "addx 3", "addx -5", "addx +07", "addx", "noop 1"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, row string) {
+		instruction, err := getInstructionFromString(1, row)
+		if err != nil {
+			return
+		}
+		reparsed, err := getInstructionFromString(1, instruction.String())
+		if err != nil {
+			t.Fatalf("getInstructionFromString(%q) failed on its own output: %v", instruction.String(), err)
+		}
+		if reparsed != instruction {
+			t.Errorf("round trip of %q gave %v, want %v", row, reparsed, instruction)
+		}
+	})
+}
+
+func FuzzGetInstructionsFromStringArray(f *testing.F) {
+	f.Add(readExample(f, "day10.txt"))
+	f.Add("noop\naddx 3\naddx -5")
+
+	f.Fuzz(func(t *testing.T, program string) {
+		instructions, err := getInstructionsFromStringArray(strings.Split(program, "\n"))
+		if err != nil {
+			return
+		}
+		rows := make([]string, len(*instructions))
+		for idx, instruction := range *instructions {
+			rows[idx] = instruction.String()
+		}
+		reparsed, err := getInstructionsFromStringArray(rows)
+		if err != nil {
+			t.Fatalf("getInstructionsFromStringArray failed on its own output: %v", err)
+		}
+		if !reflect.DeepEqual(reparsed, instructions) {
+			t.Errorf("round trip of %q gave %v, want %v", program, *reparsed, *instructions)
+		}
+	})
+}
+
+// getRegisterDuringCycles writes out the x register for every cycle, the
+// first cycle is at index 0.
+func getRegisterDuringCycles(input *PuzzleInput) ([]int, error) {
+	xRegister := 1
+	cycles := []int{}
+	for _, row := range input.Lines() {
+		var value int
+		if row == "noop" {
+			cycles = append(cycles, xRegister)
+		} else if _, err := fmt.Sscanf(row, "addx %d", &value); err == nil {
+			cycles = append(cycles, xRegister, xRegister)
+			xRegister += value
+		} else {
+			return nil, fmt.Errorf("not an instruction: %q", row)
+		}
+	}
+	return cycles, nil
+}
+
+func day10_part1_naive(input *PuzzleInput) (string, error) {
+	cycles, err := getRegisterDuringCycles(input)
+	if err != nil {
+		return "", err
+	}
+	sum := 0
+	for cycle := 20; cycle <= len(cycles); cycle += 40 {
+		sum += cycle * cycles[cycle-1]
+	}
+	return strconv.Itoa(sum), nil
+}
+
+func day10_part2_naive(input *PuzzleInput) (string, error) {
+	cycles, err := getRegisterDuringCycles(input)
+	if err != nil {
+		return "", err
+	}
+	screen := []string{}
+	for row := 0; row < 6; row++ {
+		pixels := ""
+		for column := 0; column < 40; column++ {
+			cycle := row*40 + column
+			if cycle < len(cycles) && abs(cycles[cycle]-column) <= 1 {
+				pixels += "#"
+			} else {
+				pixels += "."
+			}
+		}
+		screen = append(screen, pixels)
+	}
+	return strings.Join(screen, "\n"), nil
+}
@@ -1,7 +1,5 @@
-
 package main
 
-
 import (
 	"fmt"
 	"log"
@@ -34,128 +32,229 @@ func (m *Monkey) String() string {
 	return fmt.Sprintf("MonkeyId: %v items: %v inspectCounter: %v", m.monkeyId, m.items, m.inspectCounter)
 }
 
-func getMonkeyIdFromString(row string) uint64 {
-	re := regexp.MustCompile(`Monkey (\d+):`)
-	match := re.FindStringSubmatch(row)
-	if len(match) != 2 {
-		log.Fatal("Could not parse monkey id: ", row)
+var (
+	monkeyIdRegexp       = regexp.MustCompile(`^Monkey (\d+):$`)
+	operationRegexp      = regexp.MustCompile(`^  Operation: new = old ([+*]) (old|\d+)$`)
+	testValueRegexp      = regexp.MustCompile(`^  Test: divisible by (\d+)$`)
+	throwToMonkeyRegexps = map[bool]*regexp.Regexp{
+		true:  regexp.MustCompile(`^    If true: throw to monkey (\d+)$`),
+		false: regexp.MustCompile(`^    If false: throw to monkey (\d+)$`),
 	}
-	monkeyId, err := strconv.ParseUint(match[1], 10, 64)
+)
+
+// parseMatchedNumber parses the number captured by the first group of a
+// regexp that only matches digits there.
+func parseMatchedNumber(re *regexp.Regexp, line int, row string, expected string) (uint64, error) {
+	match := re.FindStringSubmatchIndex(row)
+	if match == nil {
+		return 0, newParseError(line, 1, row, row, expected)
+	}
+	value, err := strconv.ParseUint(row[match[2]:match[3]], 10, 64)
 	if err != nil {
-		log.Fatal(err)
+		return 0, newParseError(line, match[2]+1, row, row[match[2]:match[3]], "a number that fits in 64 bits")
 	}
-	return monkeyId
+	return value, nil
+}
+
+func getMonkeyIdFromString(line int, row string) (uint64, error) {
+	return parseMatchedNumber(monkeyIdRegexp, line, row, "\"Monkey N:\"")
 }
 
-func getItemsFromString(row string) []uint64 {
+func getItemsFromString(line int, row string) ([]uint64, error) {
 	prefixString := "  Starting items: "
+	if !strings.HasPrefix(row, prefixString) {
+		return nil, newParseError(line, 1, row, row, "\"  Starting items: N, N, ...\"")
+	}
 	items := strings.Split(row[len(prefixString):], ", ")
 	itemsInt := make([]uint64, len(items))
+	column := len(prefixString) + 1
 	for idx, item := range items {
 		itemInt, err := strconv.ParseUint(item, 10, 64)
 		if err != nil {
-			log.Fatal(err)
+			return nil, newParseError(line, column, row, item, "a worry level")
 		}
 		itemsInt[idx] = itemInt
+		column += len(item) + 2
 	}
-	return itemsInt
+	return itemsInt, nil
 }
 
-func getOperationsFromString(row string) (WorryOperation, uint64) {
-	parsedString := strings.Split(row, " ")
+func getOperationsFromString(line int, row string) (WorryOperation, uint64, error) {
+	match := operationRegexp.FindStringSubmatch(row)
+	if match == nil {
+		return 0, 0, newParseError(line, 1, row, row, "\"  Operation: new = old <+ or *> <N or old>\"")
+	}
 
 	var value uint64
 	var operation WorryOperation
 	var old bool
 
-	if parsedString[len(parsedString)-1] == "old" {
+	if match[2] == "old" {
 		old = true
 	} else {
 		var err error
-		value, err = strconv.ParseUint(parsedString[len(parsedString)-1], 10, 64)
+		value, err = strconv.ParseUint(match[2], 10, 64)
 		if err != nil {
-			log.Fatal(err)
+			return 0, 0, newParseError(line, len(row)-len(match[2])+1, row, match[2], "a number that fits in 64 bits")
 		}
 	}
 
-	if parsedString[len(parsedString)-2] == "+" {
+	if match[1] == "+" {
 		if old {
 			value = 2
 			operation = MULTIPLY
 		} else {
 			operation = PLUS
 		}
-	} else if parsedString[len(parsedString)-2] == "*" {
+	} else {
 		if old {
 			operation = SQUARED
 		} else {
 			operation = MULTIPLY
 		}
-	} else {
-		log.Fatal("Unknown operation")
 	}
 
-	return operation, value
+	return operation, value, nil
 }
 
-func getTestValueFromString(row string) uint64 {
-	re := regexp.MustCompile(`  Test: divisible by (\d+)`)
-	match := re.FindStringSubmatch(row)
-	if len(match) != 2 {
-		log.Fatal("Could not parse test value: ", row)
+func getTestValueFromString(line int, row string) (uint64, error) {
+	value, err := parseMatchedNumber(testValueRegexp, line, row, "\"  Test: divisible by N\"")
+	if err == nil && value == 0 {
+		return 0, newParseError(line, len(row), row, "0", "a divisor larger than 0")
 	}
+	return value, err
+}
 
-	value, err := strconv.ParseUint(match[1], 10, 64)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return value
+func getThrowToMonkeyFromString(line int, row string, condition bool) (uint64, error) {
+	return parseMatchedNumber(throwToMonkeyRegexps[condition], line, row, fmt.Sprintf("\"    If %t: throw to monkey N\"", condition))
 }
 
-func getThrowToMonkeyFromString(row string) uint64 {
-	re := regexp.MustCompile(`throw to monkey (\d+)`)
-	match := re.FindStringSubmatch(row)
-	if len(match) != 2 {
-		log.Fatal("Could not parse throw to monkey: ", row)
+// readMonkey reads the six rows of a monkey and returns every row that could
+// not be read.
+func readMonkey(paragraph Paragraph, expectedId int) (Monkey, []error) {
+	rows := paragraph.rows
+	line := paragraph.line
+	if len(rows) != 6 {
+		lastRow := rows[len(rows)-1]
+		if len(rows) > 6 {
+			return Monkey{}, []error{newParseError(line+6, 1, rows[6], rows[6], "an empty row between monkeys")}
+		}
+		return Monkey{}, []error{newParseError(line+len(rows)-1, len(lastRow)+1, lastRow, "", "six rows describing a monkey")}
 	}
 
-	value, err := strconv.ParseUint(match[1], 10, 64)
+	var errs []error
+	monkeyId, err := getMonkeyIdFromString(line, rows[0])
+	if err == nil && monkeyId != uint64(expectedId) {
+		err = newParseError(line, 8, rows[0], strconv.FormatUint(monkeyId, 10), fmt.Sprintf("monkey %d", expectedId))
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	items, err := getItemsFromString(line+1, rows[1])
+	if err != nil {
+		errs = append(errs, err)
+	}
+	worryOperation, worryValueModifier, err := getOperationsFromString(line+2, rows[2])
+	if err != nil {
+		errs = append(errs, err)
+	}
+	testValue, err := getTestValueFromString(line+3, rows[3])
+	if err != nil {
+		errs = append(errs, err)
+	}
+	throwToMonkeyTrue, err := getThrowToMonkeyFromString(line+4, rows[4], true)
 	if err != nil {
-		log.Fatal(err)
+		errs = append(errs, err)
 	}
-	return value
+	throwToMonkeyFalse, err := getThrowToMonkeyFromString(line+5, rows[5], false)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	monkey := Monkey{monkeyId: monkeyId,
+		items:              items,
+		worryOperation:     worryOperation,
+		worryValueModifier: worryValueModifier,
+		testValue:          testValue,
+		throwToMonkeyTrue:  throwToMonkeyTrue,
+		throwToMonkeyFalse: throwToMonkeyFalse,
+		inspectCounter:     0}
+	return monkey, errs
 }
 
-func parseStringsForMonkeys(rows []string) *[]Monkey {
-	monkeys := make([]Monkey, 0)
+// readMonkeys reads every monkey, and checks that the monkeys only throw to
+// other monkeys that exist.
+func readMonkeys(paragraphs []Paragraph) ([]Monkey, []error) {
+	if len(paragraphs) == 0 {
+		return nil, []error{newParseError(1, 1, "", "", "\"Monkey 0:\"")}
+	}
 
-	idx := 0
-	for {
-		if idx >= len(rows) {
-			break
+	monkeys := make([]Monkey, 0)
+	var errs []error
+	for idx, paragraph := range paragraphs {
+		monkey, monkeyErrs := readMonkey(paragraph, idx)
+		errs = append(errs, monkeyErrs...)
+		if len(monkeyErrs) > 0 {
+			continue
 		}
 
-		monkeyId := getMonkeyIdFromString(rows[idx])
-		items := getItemsFromString(rows[idx+1])
-		worryOperation, worryValueModifier := getOperationsFromString(rows[idx+2])
-		testValue := getTestValueFromString(rows[idx+3])
-		throwToMonkeyTrue := getThrowToMonkeyFromString(rows[idx+4])
-		throwToMonkeyFalse := getThrowToMonkeyFromString(rows[idx+5])
-
-		monkey := Monkey{monkeyId: monkeyId,
-			items:              items,
-			worryOperation:     worryOperation,
-			worryValueModifier: worryValueModifier,
-			testValue:          testValue,
-			throwToMonkeyTrue:  throwToMonkeyTrue,
-			throwToMonkeyFalse: throwToMonkeyFalse,
-			inspectCounter:     0}
-
+		for offset, target := range []uint64{monkey.throwToMonkeyTrue, monkey.throwToMonkeyFalse} {
+			if target >= uint64(len(paragraphs)) || target == monkey.monkeyId {
+				row := paragraph.rows[4+offset]
+				targetString := strconv.FormatUint(target, 10)
+				errs = append(errs, newParseError(paragraph.line+4+offset, len(row)-len(targetString)+1, row, targetString, "another monkey that exists"))
+			}
+		}
 		monkeys = append(monkeys, monkey)
-		idx += 7
 	}
+	return monkeys, errs
+}
+
+func parseStringsForMonkeys(paragraphs []Paragraph) (*[]Monkey, error) {
+	monkeys, errs := readMonkeys(paragraphs)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return &monkeys, nil
+}
 
-	return &monkeys
+func lintDay11(input *PuzzleInput) []error {
+	_, errs := readMonkeys(input.Paragraphs())
+	return errs
+}
+
+// formatMonkeys writes the monkeys the same way as the puzzle input.
+func formatMonkeys(monkeys []Monkey) []string {
+	rows := make([]string, 0, 7*len(monkeys))
+	for idx, monkey := range monkeys {
+		if idx > 0 {
+			rows = append(rows, "")
+		}
+
+		items := make([]string, len(monkey.items))
+		for jdx, item := range monkey.items {
+			items[jdx] = strconv.FormatUint(item, 10)
+		}
+
+		var operation string
+		switch monkey.worryOperation {
+		case PLUS:
+			operation = fmt.Sprintf("old + %d", monkey.worryValueModifier)
+		case MULTIPLY:
+			operation = fmt.Sprintf("old * %d", monkey.worryValueModifier)
+		case SQUARED:
+			operation = "old * old"
+		}
+
+		rows = append(rows,
+			fmt.Sprintf("Monkey %d:", monkey.monkeyId),
+			"  Starting items: "+strings.Join(items, ", "),
+			"  Operation: new = "+operation,
+			fmt.Sprintf("  Test: divisible by %d", monkey.testValue),
+			fmt.Sprintf("    If true: throw to monkey %d", monkey.throwToMonkeyTrue),
+			fmt.Sprintf("    If false: throw to monkey %d", monkey.throwToMonkeyFalse))
+	}
+	return rows
 }
 
 func (m *Monkey) throwItem(superMod uint64) (uint64, uint64) {
@@ -171,7 +270,7 @@ func (m *Monkey) throwItem(superMod uint64) (uint64, uint64) {
 	case SQUARED:
 		item = item * item
 	default:
-		log.Fatalln("Not a valid worry operation")
+		log.Panicln("Not a valid worry operation")
 	}
 
 	if superMod == 0 {
@@ -209,7 +308,7 @@ func runMonkeyRounds(monkeys *[]Monkey, rounds int, superMod uint64) {
 
 				recevingMonkey := &(*monkeys)[toThrowMonkeyId]
 				if recevingMonkey.monkeyId != toThrowMonkeyId {
-					log.Fatalf("Wrong monkey id")
+					log.Panicf("Wrong monkey id")
 				}
 
 				recevingMonkey.items = append(recevingMonkey.items, item)
@@ -218,14 +317,11 @@ func runMonkeyRounds(monkeys *[]Monkey, rounds int, superMod uint64) {
 	}
 }
 
-func day11_part1() {
-	rows, err := getRowsFromFile("input11.txt")
-
-	if err != nil {
-		log.Fatal(err)
-	}
+func parseDay11(input *PuzzleInput) (*[]Monkey, error) {
+	return parseStringsForMonkeys(input.Paragraphs())
+}
 
-	monkeys := parseStringsForMonkeys(rows[:len(rows)-1])
+func day11_part1(monkeys *[]Monkey) (Answer, error) {
 	runMonkeyRounds(monkeys, 20, 0)
 
 	sort.Slice(*monkeys, func(i, j int) bool {
@@ -233,7 +329,7 @@ func day11_part1() {
 	})
 
 	monkeyBusiness := (*monkeys)[0].inspectCounter * (*monkeys)[1].inspectCounter
-	fmt.Println("Monkey business: ", monkeyBusiness)
+	return newIntAnswer(int(monkeyBusiness)), nil
 }
 
 func getSuperMod(monkeys *[]Monkey) uint64 {
@@ -245,15 +341,7 @@ func getSuperMod(monkeys *[]Monkey) uint64 {
 	return superMod
 }
 
-func day11_part2() {
-	rows, err := getRowsFromFile("input11.txt")
-
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	monkeys := parseStringsForMonkeys(rows[:len(rows)-1])
-
+func day11_part2(monkeys *[]Monkey) (Answer, error) {
 	runMonkeyRounds(monkeys, 10000, getSuperMod(monkeys))
 
 	sort.Slice(*monkeys, func(i, j int) bool {
@@ -261,5 +349,5 @@ func day11_part2() {
 	})
 
 	monkeyBusiness := (*monkeys)[0].inspectCounter * (*monkeys)[1].inspectCounter
-	fmt.Println("Monkey business: ", monkeyBusiness)
+	return newIntAnswer(int(monkeyBusiness)), nil
 }
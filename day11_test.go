@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func FuzzParseStringsForMonkeys(f *testing.F) {
+	f.Add(readExample(f, "day11.txt"))
+	f.Add("Monkey 0:\n  Starting items: 1\n  Operation: new = old + old\n  Test: divisible by 2\n    If true: throw to monkey 1\n    If false: throw to monkey 1\n\n" +
+		"Monkey 1:\n  Starting items: 18446744073709551615\n  Operation: new = old * old\n  Test: divisible by 3\n    If true: throw to monkey 0\n    If false: throw to monkey 0")
+
+	f.Fuzz(func(t *testing.T, notes string) {
+		monkeys, err := parseStringsForMonkeys(newPuzzleInput([]byte(notes)).Paragraphs())
+		if err != nil {
+			return
+		}
+		formatted := strings.Join(formatMonkeys(*monkeys), "\n")
+		reparsed, err := parseStringsForMonkeys(newPuzzleInput([]byte(formatted)).Paragraphs())
+		if err != nil {
+			t.Fatalf("parseStringsForMonkeys failed on its own output: %v", err)
+		}
+		if !reflect.DeepEqual(reparsed, monkeys) {
+			t.Errorf("round trip of %q gave %v, want %v", notes, *reparsed, *monkeys)
+		}
+	})
+}
+
+func BenchmarkRunMonkeyRounds(b *testing.B) {
+	input := newPuzzleInput(readEmbeddedInput(b, 11))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		// the rounds move the items around, so every run starts from a fresh parse
+		b.StopTimer()
+		monkeys, err := parseDay11(input)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+		runMonkeyRounds(monkeys, 10000, getSuperMod(monkeys))
+	}
+}
+
+type NaiveMonkey struct {
+	items     []*big.Int
+	operation string
+	operand   string
+	constant  int64
+	divisor   int64
+	targets   [2]int
+	inspected int
+}
+
+// getNaiveMonkeys reads the notes field by field, every monkey has to throw
+// to other monkeys that exist.
+func getNaiveMonkeys(input *PuzzleInput) ([]*NaiveMonkey, error) {
+	monkeys := []*NaiveMonkey{}
+	for idx, paragraph := range input.Paragraphs() {
+		rows := paragraph.rows
+		if len(rows) != 6 || rows[0] != fmt.Sprintf("Monkey %d:", idx) {
+			return nil, fmt.Errorf("not monkey %d: %q", idx, rows)
+		}
+		monkey := &NaiveMonkey{}
+		for _, item := range strings.Split(strings.TrimPrefix(rows[1], "  Starting items: "), ", ") {
+			worry, ok := new(big.Int).SetString(item, 10)
+			if !ok || worry.Sign() < 0 {
+				return nil, fmt.Errorf("not an item: %q", item)
+			}
+			monkey.items = append(monkey.items, worry)
+		}
+		if _, err := fmt.Sscanf(rows[2], "  Operation: new = old %s %s", &monkey.operation, &monkey.operand); err != nil {
+			return nil, err
+		}
+		if monkey.operand != "old" {
+			constant, err := strconv.ParseInt(monkey.operand, 10, 64)
+			if err != nil || constant < 0 {
+				return nil, fmt.Errorf("not an operand: %q", monkey.operand)
+			}
+			monkey.constant = constant
+		}
+		if _, err := fmt.Sscanf(rows[3], "  Test: divisible by %d", &monkey.divisor); err != nil || monkey.divisor <= 0 {
+			return nil, fmt.Errorf("not a test: %q", rows[3])
+		}
+		if _, err := fmt.Sscanf(rows[4], "    If true: throw to monkey %d", &monkey.targets[0]); err != nil {
+			return nil, err
+		}
+		if _, err := fmt.Sscanf(rows[5], "    If false: throw to monkey %d", &monkey.targets[1]); err != nil {
+			return nil, err
+		}
+		monkeys = append(monkeys, monkey)
+	}
+
+	for idx, monkey := range monkeys {
+		for _, target := range monkey.targets {
+			if target < 0 || target >= len(monkeys) || target == idx {
+				return nil, fmt.Errorf("monkey %d throws to monkey %d", idx, target)
+			}
+		}
+	}
+	if len(monkeys) < 2 {
+		return nil, fmt.Errorf("%d monkeys", len(monkeys))
+	}
+	return monkeys, nil
+}
+
+func (m *NaiveMonkey) inspect(worry *big.Int) (*big.Int, error) {
+	operand := new(big.Int).Set(worry)
+	if m.operand != "old" {
+		operand.SetInt64(m.constant)
+	}
+	switch m.operation {
+	case "+":
+		return operand.Add(worry, operand), nil
+	case "*":
+		return operand.Mul(worry, operand), nil
+	}
+	return nil, fmt.Errorf("not an operation: %q", m.operation)
+}
+
+// inspectRemainder is inspect on the remainder of a worry level, remainders
+// below 1<<31 can be multiplied without overflowing.
+func (m *NaiveMonkey) inspectRemainder(remainder int64, divisor int64) (int64, error) {
+	operand := remainder
+	if m.operand != "old" {
+		operand = m.constant % divisor
+	}
+	switch m.operation {
+	case "+":
+		return (remainder + operand) % divisor, nil
+	case "*":
+		return (remainder * operand) % divisor, nil
+	}
+	return 0, fmt.Errorf("not an operation: %q", m.operation)
+}
+
+func getMonkeyBusiness(monkeys []*NaiveMonkey) string {
+	inspected := []int{}
+	for _, monkey := range monkeys {
+		inspected = append(inspected, monkey.inspected)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(inspected)))
+	return strconv.Itoa(inspected[0] * inspected[1])
+}
+
+// day11_part1_naive keeps the exact worry levels.
+func day11_part1_naive(input *PuzzleInput) (string, error) {
+	monkeys, err := getNaiveMonkeys(input)
+	if err != nil {
+		return "", err
+	}
+	three := big.NewInt(3)
+	for round := 0; round < 20; round++ {
+		for _, monkey := range monkeys {
+			for _, item := range monkey.items {
+				worry, err := monkey.inspect(item)
+				if err != nil {
+					return "", err
+				}
+				worry.Div(worry, three)
+				monkey.inspected++
+
+				target := monkey.targets[1]
+				if new(big.Int).Mod(worry, big.NewInt(monkey.divisor)).Sign() == 0 {
+					target = monkey.targets[0]
+				}
+				monkeys[target].items = append(monkeys[target].items, worry)
+			}
+			monkey.items = nil
+		}
+	}
+	return getMonkeyBusiness(monkeys), nil
+}
+
+// day11_part2_naive keeps, for every item, the remainder by the divisor of
+// every monkey instead of a single remainder by their product.
+func day11_part2_naive(input *PuzzleInput) (string, error) {
+	monkeys, err := getNaiveMonkeys(input)
+	if err != nil {
+		return "", err
+	}
+	type Item []int64
+	holding := make([][]Item, len(monkeys))
+	for idx, monkey := range monkeys {
+		if monkey.divisor >= 1<<31 {
+			return "", fmt.Errorf("divisor %d is too large to multiply remainders", monkey.divisor)
+		}
+		for _, worry := range monkey.items {
+			item := Item{}
+			for _, other := range monkeys {
+				item = append(item, new(big.Int).Mod(worry, big.NewInt(other.divisor)).Int64())
+			}
+			holding[idx] = append(holding[idx], item)
+		}
+	}
+
+	for round := 0; round < 10000; round++ {
+		for idx, monkey := range monkeys {
+			for _, item := range holding[idx] {
+				for jdx, remainder := range item {
+					item[jdx], err = monkey.inspectRemainder(remainder, monkeys[jdx].divisor)
+					if err != nil {
+						return "", err
+					}
+				}
+				monkey.inspected++
+
+				target := monkey.targets[1]
+				if item[idx] == 0 {
+					target = monkey.targets[0]
+				}
+				holding[target] = append(holding[target], item)
+			}
+			holding[idx] = nil
+		}
+	}
+	return getMonkeyBusiness(monkeys), nil
+}
@@ -1,44 +1,88 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"strings"
 )
 
-func getHeightMapFromRows(rows []string) [][]string {
-    heightMap := make([][]string, len(rows))
+func lintHeightMapRows(rows []string) []error {
+	if len(rows) == 0 {
+		return []error{newParseError(1, 1, "", "", "a height map")}
+	}
 
-    for i, row := range rows {
-        heightMap[i] = make([]string, len(row))
-        for j, char := range row {
-            heightMap[i][j] = string(char)
-        }
-    }
+	var errs []error
+	starts, finishes := 0, 0
+	for i, row := range rows {
+		if len(row) != len(rows[0]) {
+			errs = append(errs, newParseError(i+1, 1, row, row, fmt.Sprintf("a row of %d heights", len(rows[0]))))
+		}
+		for j, char := range row {
+			switch {
+			case char == 'S':
+				starts++
+			case char == 'E':
+				finishes++
+			case char < 'a' || char > 'z':
+				errs = append(errs, newParseError(i+1, j+1, row, string(char), "a height between a-z, S or E"))
+				continue
+			}
+			if (char == 'S' && starts > 1) || (char == 'E' && finishes > 1) {
+				errs = append(errs, newParseError(i+1, j+1, row, string(char), "a single start S and a single end E"))
+			}
+		}
+	}
+	if starts == 0 || finishes == 0 {
+		lastRow := rows[len(rows)-1]
+		errs = append(errs, newParseError(len(rows), len(lastRow)+1, lastRow, "", "a map with a start S and an end E"))
+	}
+	return errs
+}
 
-    return heightMap
+func getHeightMapFromRows(rows []string) ([][]string, error) {
+	if errs := lintHeightMapRows(rows); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	heightMap := make([][]string, len(rows))
+	for i, row := range rows {
+		heightMap[i] = make([]string, len(row))
+		for j, char := range row {
+			heightMap[i][j] = string(char)
+		}
+	}
+	return heightMap, nil
+}
+
+func formatHeightMap(heightMap [][]string) []string {
+	rows := make([]string, len(heightMap))
+	for i, row := range heightMap {
+		rows[i] = strings.Join(row, "")
+	}
+	return rows
 }
 
 func findHeightPosition(height string, heightMap [][]string) Position {
-    for i, row := range heightMap {
-        for j, char := range row {
-            if char == height {
-                return Position{i, j}
-            }
-        }
-    }
-
-    return Position{-1, -1}
+	for i, row := range heightMap {
+		for j, char := range row {
+			if char == height {
+				return Position{i, j}
+			}
+		}
+	}
+
+	return Position{-1, -1}
 }
 
 var directions = []Position{
-    {-1, 0},
-    {1, 0},
-    {0, 1},
-    {0, -1},
+	{-1, 0},
+	{1, 0},
+	{0, 1},
+	{0, -1},
 }
 
 func isValidNextPosition(next Position, rows, cols int) bool {
-    return next.x >= 0 && next.x < rows && next.y >= 0 && next.y < cols
+	return next.x >= 0 && next.x < rows && next.y >= 0 && next.y < cols
 }
 
 func distanceBetweenLetters(x, y string) int {
@@ -55,77 +99,72 @@ func distanceBetweenLetters(x, y string) int {
 		x = "z"
 	}
 
-    asciiX := int(x[0])
+	asciiX := int(x[0])
 	asciiY := int(y[0])
 
 	return asciiY - asciiX
 }
 
-
 type HeightMapPosition struct {
-    position Position
-    distance int
+	position Position
+	distance int
 }
 
 func breadthFirstSearch(heightMap [][]string) int {
-    rows := len(heightMap)
-    cols := len(heightMap[0])
-
-    visited := map[Position]bool{}
-
-    start := findHeightPosition("S", heightMap)
-    finish := findHeightPosition("E", heightMap)
-
-    queue := []HeightMapPosition{{start, 0}}
-    for len(queue) > 0 {
-        current := queue[0]
-        queue = queue[1:]
-
-        currentDistance := current.distance
-        currentPosition := current.position
-        currentHeight := heightMap[currentPosition.x][currentPosition.y]
-
-        if visited[currentPosition] {
-            continue
-        }
-
-        visited[currentPosition] = true
-
-        if currentPosition == finish {
-            return currentDistance
-        }
-
-        for _, dir := range directions {
-            nextPosition := Position{currentPosition.x + dir.x, currentPosition.y + dir.y}
-            if isValidNextPosition(nextPosition, rows, cols) {
-                nextHeight := heightMap[nextPosition.x][nextPosition.y]
-                if distanceBetweenLetters(currentHeight, nextHeight) <= 1 {
-                    queue = append(queue, HeightMapPosition{nextPosition, currentDistance + 1})
-                }
-            }
-        }
-    }
+	rows := len(heightMap)
+	cols := len(heightMap[0])
+
+	visited := map[Position]bool{}
+
+	start := findHeightPosition("S", heightMap)
+	finish := findHeightPosition("E", heightMap)
+
+	queue := []HeightMapPosition{{start, 0}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		currentDistance := current.distance
+		currentPosition := current.position
+		currentHeight := heightMap[currentPosition.x][currentPosition.y]
+
+		if visited[currentPosition] {
+			continue
+		}
+
+		visited[currentPosition] = true
+
+		if currentPosition == finish {
+			return currentDistance
+		}
+
+		for _, dir := range directions {
+			nextPosition := Position{currentPosition.x + dir.x, currentPosition.y + dir.y}
+			if isValidNextPosition(nextPosition, rows, cols) {
+				nextHeight := heightMap[nextPosition.x][nextPosition.y]
+				if distanceBetweenLetters(currentHeight, nextHeight) <= 1 {
+					queue = append(queue, HeightMapPosition{nextPosition, currentDistance + 1})
+				}
+			}
+		}
+	}
 
-    return -1
+	return -1
 }
 
-func day12_part1() {
-	rows, err := getRowsFromFile("input12.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
+func parseDay12(input *PuzzleInput) ([][]string, error) {
+	return getHeightMapFromRows(input.Lines())
+}
 
-	heightMap := getHeightMapFromRows(rows[:len(rows)-1])
+func day12_part1(heightMap [][]string) (Answer, error) {
 	distance := breadthFirstSearch(heightMap)
-	fmt.Println(getFunctionName(), " solution: ", distance)
+	return newIntAnswer(distance), nil
 }
 
-func day12_part2() {
-	// rows, err := getRowsFromFile("input11.txt")
-
-	// if err != nil {
-	// 	log.Fatal(err)
-	// }
+func day12_part2(heightMap [][]string) (Answer, error) {
+	return Answer{}, errors.New("day 12 part 2 is not solved yet")
+}
 
-	// fmt.Println(getFunctionName(), " solution: ", solution)
+func lintDay12(input *PuzzleInput) []error {
+	return lintHeightMapRows(input.Lines())
 }
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBreadthFirstSearch(t *testing.T) {
+	tests := []struct {
+		name string
+		rows []string
+		want int
+	}{
+		{"example", []string{"Sabqponm", "abcryxxl", "accszExk", "acctuvwj", "abdefghi"}, 31},
+		{"straight line", []string{"SbcdefghijklmnopqrstuvwxyE"}, 25},
+		{"wall too high", []string{"SacE"}, -1},
+		{"step down", []string{"SzE", "bcd"}, -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			heightMap, err := getHeightMapFromRows(test.rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := breadthFirstSearch(heightMap); got != test.want {
+				t.Errorf("breadthFirstSearch() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func FuzzGetHeightMapFromRows(f *testing.F) {
+	f.Add(readExample(f, "day12.txt"))
+	f.Add("SE")
+	f.Add("Sa\nbE")
+
+	f.Fuzz(func(t *testing.T, heights string) {
+		heightMap, err := getHeightMapFromRows(strings.Split(heights, "\n"))
+		if err != nil {
+			return
+		}
+		reparsed, err := getHeightMapFromRows(formatHeightMap(heightMap))
+		if err != nil {
+			t.Fatalf("getHeightMapFromRows failed on its own output: %v", err)
+		}
+		if !reflect.DeepEqual(reparsed, heightMap) {
+			t.Errorf("round trip of %q gave %v, want %v", heights, reparsed, heightMap)
+		}
+	})
+}
+
+func BenchmarkBreadthFirstSearch(b *testing.B) {
+	heightMap, err := parseDay12(newPuzzleInput(readEmbeddedInput(b, 12)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		breadthFirstSearch(heightMap)
+	}
+}
+
+// day12_part1_naive runs Dijkstra, picking the closest open square by looking
+// at all of them.
+func day12_part1_naive(input *PuzzleInput) (string, error) {
+	rows := input.Lines()
+	if len(rows) == 0 || strings.Count(strings.Join(rows, ""), "S") != 1 || strings.Count(strings.Join(rows, ""), "E") != 1 {
+		return "", fmt.Errorf("the map needs one S and one E")
+	}
+	heights := map[Position]int{}
+	distances := map[Position]int{}
+	var start, finish Position
+	for x, row := range rows {
+		if len(row) != len(rows[0]) {
+			return "", fmt.Errorf("row %d is not as wide as the first", x+1)
+		}
+		for y, square := range row {
+			position := Position{x, y}
+			switch {
+			case square == 'S':
+				start, heights[position] = position, 0
+			case square == 'E':
+				finish, heights[position] = position, 25
+			case 'a' <= square && square <= 'z':
+				heights[position] = int(square - 'a')
+			default:
+				return "", fmt.Errorf("not a height: %q", square)
+			}
+			distances[position] = -1
+		}
+	}
+
+	distances[start] = 0
+	done := map[Position]bool{}
+	for {
+		current, found := Position{}, false
+		for position, distance := range distances {
+			if distance >= 0 && !done[position] && (!found || distance < distances[current]) {
+				current, found = position, true
+			}
+		}
+		if !found {
+			return "-1", nil
+		}
+		if current == finish {
+			return strconv.Itoa(distances[current]), nil
+		}
+		done[current] = true
+		for _, direction := range directions {
+			next := Position{current.x + direction.x, current.y + direction.y}
+			height, ok := heights[next]
+			if !ok || height > heights[current]+1 {
+				continue
+			}
+			if distances[next] < 0 || distances[current]+1 < distances[next] {
+				distances[next] = distances[current] + 1
+			}
+		}
+	}
+}
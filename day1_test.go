@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// getSortedElvesCalories sums every elf and sorts the sums, largest first.
+func getSortedElvesCalories(input *PuzzleInput) ([]int, error) {
+	elves := []int{}
+	for _, paragraph := range input.Paragraphs() {
+		sum := 0
+		for _, row := range paragraph.rows {
+			calories, err := strconv.Atoi(row)
+			if err != nil || calories < 0 {
+				return nil, fmt.Errorf("not a number of calories: %q", row)
+			}
+			sum += calories
+		}
+		elves = append(elves, sum)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(elves)))
+	return elves, nil
+}
+
+func day1_part1_naive(input *PuzzleInput) (string, error) {
+	elves, err := getSortedElvesCalories(input)
+	if err != nil || len(elves) == 0 {
+		return "", fmt.Errorf("no elves: %v", err)
+	}
+	return strconv.Itoa(elves[0]), nil
+}
+
+func day1_part2_naive(input *PuzzleInput) (string, error) {
+	elves, err := getSortedElvesCalories(input)
+	if err != nil {
+		return "", err
+	}
+	sum := 0
+	for idx := 0; idx < 3 && idx < len(elves); idx++ {
+		sum += elves[idx]
+	}
+	return strconv.Itoa(sum), nil
+}
+
+func TestGetTopElves(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		n     int
+		want  []ElfCalories
+	}{
+		{"last elf without a blank row", "1\n\n2\n3", 1, []ElfCalories{{2, 2, 5}}},
+		{"ties keep the list order", "5\n\n7\n\n5\n\n7", 3, []ElfCalories{{2, 1, 7}, {4, 1, 7}, {1, 1, 5}}},
+		{"fewer elves than n", "1\n2\n\n\n\n4", 3, []ElfCalories{{2, 1, 4}, {1, 2, 3}}},
+		{"n is 0", "1\n\n2", 0, []ElfCalories{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := getTopElves(newLineScanner(strings.NewReader(test.input), maxLineLength), test.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("getTopElves() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetCalorieStats(t *testing.T) {
+	file, err := os.Open(filepath.Join("testdata", "day1.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	stats, err := getCalorieStats(newLineScanner(file, maxLineLength))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Elves != 5 || stats.Items != 10 || stats.TotalCalories != 55000 {
+		t.Errorf("got %d elves, %d items and %d calories, want 5, 10 and 55000", stats.Elves, stats.Items, stats.TotalCalories)
+	}
+	if stats.Mean != 11000 || stats.Median != 10000 || math.Abs(stats.StandardDeviation-6985.7) > 0.1 {
+		t.Errorf("got mean %v, median %v and deviation %v", stats.Mean, stats.Median, stats.StandardDeviation)
+	}
+	if stats.MostItems != (ElfRecord{1, 3, 6000}) || stats.FewestItems != (ElfRecord{2, 1, 4000}) {
+		t.Errorf("got most items %v and fewest items %v", stats.MostItems, stats.FewestItems)
+	}
+	if p90 := stats.Percentiles[3]; p90 != (PercentileRecord{90, 24000}) {
+		t.Errorf("got %v, want p90 of 24000", p90)
+	}
+	counts := []int{}
+	for _, bucket := range stats.Histogram {
+		counts = append(counts, bucket.Elves)
+	}
+	if want := []int{2, 0, 1, 1, 0, 0, 0, 0, 0, 1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("histogram counts %v, want %v", counts, want)
+	}
+}
+
+func TestElfRanking(t *testing.T) {
+	file, err := os.Open(filepath.Join("testdata", "day1.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	ranking, err := getElfRanking(newLineScanner(file, maxLineLength))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ranking.sumTop(3); got != 45000 {
+		t.Errorf("sumTop(3) = %d, want 45000", got)
+	}
+	if got, ok := ranking.rank(3); !ok || got != 2 {
+		t.Errorf("rank(3) = %d, %v, want 2, true", got, ok)
+	}
+
+	ranking.addItem(2, 20000)
+	ranking.removeElf(4)
+	if got := ranking.sumTop(2); got != 35000 {
+		t.Errorf("sumTop(2) = %d after the changes, want 35000", got)
+	}
+	if got, ok := ranking.rank(2); !ok || got != 1 {
+		t.Errorf("rank(2) = %d, %v after the changes, want 1, true", got, ok)
+	}
+	if _, ok := ranking.rank(4); ok {
+		t.Error("removed elf 4 is still ranked")
+	}
+}
+
+// TestElfRankingAgainstSorting applies random changes and compares every
+// rank and top k sum with sorting all the elves again.
+func TestElfRankingAgainstSorting(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ranking := newElfRanking()
+	elves := map[int]ElfCalories{}
+	for step := 0; step < 2000; step++ {
+		elf := 1 + rng.Intn(50)
+		if rng.Intn(5) == 0 {
+			delete(elves, elf)
+			ranking.removeElf(elf)
+		} else {
+			calories := rng.Intn(100)
+			current := elves[elf]
+			elves[elf] = ElfCalories{elf: elf, items: current.items + 1, calories: current.calories + calories}
+			ranking.addItem(elf, calories)
+		}
+
+		sorted := []ElfCalories{}
+		for _, elf := range elves {
+			sorted = append(sorted, elf)
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].isRankedBefore(sorted[j])
+		})
+		if ranking.len() != len(sorted) {
+			t.Fatalf("step %d: %d elves ranked, want %d", step, ranking.len(), len(sorted))
+		}
+		sum := 0
+		for idx, elf := range sorted {
+			if got, ok := ranking.rank(elf.elf); !ok || got != idx+1 {
+				t.Fatalf("step %d: rank(%d) = %d, %v, want %d", step, elf.elf, got, ok, idx+1)
+			}
+			sum += elf.calories
+			if got := ranking.sumTop(idx + 1); got != sum {
+				t.Fatalf("step %d: sumTop(%d) = %d, want %d", step, idx+1, got, sum)
+			}
+		}
+	}
+}
@@ -1,85 +1,72 @@
 package main
 
 import (
-	"fmt"
-	"log"
+	"strings"
 )
 
-type Hand int
-
-const (
-	Rock Hand = iota
-	Paper
-	Scissors
-)
-
-func (h Hand) String() string {
-	return [...]string{"Rock", "Paper", "Scissors"}[h]
+type StrategyGuideRow struct {
+	opponent byte
+	response byte
 }
 
-func convertByteToHand(handByte byte) Hand {
-	if handByte == 'A' || handByte == 'X' {
-		return Rock
-	} else if handByte == 'B' || handByte == 'Y' {
-		return Paper
-	} else {
-		return Scissors
+// formatSymbols lists the symbols for a parse error, as in "A, B or C".
+func formatSymbols(symbols string) string {
+	letters := strings.Split(symbols, "")
+	if len(letters) == 1 {
+		return letters[0]
 	}
+	return strings.Join(letters[:len(letters)-1], ", ") + " or " + letters[len(letters)-1]
 }
 
-func getWinnerPoints(handByte Hand, you Hand) int {
-	if handByte == you {
-		return 3
-	} else if handByte == Rock && you == Paper {
-		return 6
-	} else if handByte == Paper && you == Scissors {
-		return 6
-	} else if handByte == Scissors && you == Rock {
-		return 6
-	} else {
-		return 0
+// getStrategyGuideRowFromString checks the row against the opponent symbols
+// of the game and the symbols allowed in the response column.
+func getStrategyGuideRowFromString(line int, row string, opponents string, responses string) (StrategyGuideRow, error) {
+	if len(row) != 3 {
+		return StrategyGuideRow{}, newParseError(line, 1, row, row, "a row like \"A X\"")
 	}
+	if strings.IndexByte(opponents, row[0]) < 0 {
+		return StrategyGuideRow{}, newParseError(line, 1, row, row[0:1], formatSymbols(opponents))
+	}
+	if row[1] != ' ' {
+		return StrategyGuideRow{}, newParseError(line, 2, row, row[1:2], "a space")
+	}
+	if strings.IndexByte(responses, row[2]) < 0 {
+		return StrategyGuideRow{}, newParseError(line, 3, row, row[2:3], formatSymbols(responses))
+	}
+	return StrategyGuideRow{opponent: row[0], response: row[2]}, nil
 }
 
-func getHandPoints(hand Hand) int {
-	if hand == Rock {
-		return 1
-	} else if hand == Paper {
-		return 2
-	} else {
-		return 3
+func forEachStrategyGuideRow(lines *LineScanner, opponents string, responses string, visit func(row StrategyGuideRow)) error {
+	for lines.Scan() {
+		row, err := getStrategyGuideRowFromString(lines.Line(), lines.Text(), opponents, responses)
+		if err != nil {
+			return err
+		}
+		visit(row)
 	}
+	return lines.Err()
 }
 
 // A for Rock, B for Paper, and C for Scissors
 // 1 for Rock, 2 for Paper, and 3 for Scissors
 // 0 if you lost, 3 if the round was a draw, and 6 if you won
-func day2_part1() {
-	rows, err := getRowsFromFile("input2.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-
+func day2_part1(lines *LineScanner) (Answer, error) {
+	game := day2Game
 	solution := 0
-	for _, row := range rows {
-		if row == "" {
-			continue
-		}
-		if len(row) != 3 {
-			log.Fatal("Invalid input: ", row)
-		}
-		var opponent Hand = convertByteToHand(row[0])
-		var you Hand = convertByteToHand(row[2])
+	err := forEachStrategyGuideRow(lines, game.opponentSymbols, game.responseSymbols, func(row StrategyGuideRow) {
+		var opponent Hand = game.getOpponentHand(row.opponent)
+		var you Hand = game.getResponseHand(row.response)
 
-		currentWinnerPoints := getWinnerPoints(opponent, you)
-		currentHandPoints := getHandPoints(you)
+		currentWinnerPoints := game.getWinnerPoints(opponent, you)
+		currentHandPoints := game.getHandPoints(you)
 		currentRoundPoints := currentWinnerPoints + currentHandPoints
 		solution += currentRoundPoints
-
-		// fmt.Println("Opponent: ", opponent, " You: ", you, " Winner: ", currentWinnerPoints, " Hand: ", currentHandPoints, " Round: ", currentRoundPoints)
+	})
+	if err != nil {
+		return Answer{}, err
 	}
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return newIntAnswer(solution), nil
 }
 
 type ExpectedResult int
@@ -94,36 +81,11 @@ func (e ExpectedResult) String() string {
 	return [...]string{"Lost", "Draw", "Won"}[e]
 }
 
-func convertByteToExpectedResult(expectedResultByte byte) ExpectedResult {
-	if expectedResultByte == 'X' {
-		return Lost
-	} else if expectedResultByte == 'Y' {
-		return Draw
-	} else {
-		return Won
-	}
-}
+// expectedResultSymbols are the X, Y and Z of part 2, in ExpectedResult order.
+const expectedResultSymbols = "XYZ"
 
-func getYourHand(expectedResult ExpectedResult, opponent Hand) Hand {
-	if expectedResult == Lost {
-		if opponent == Rock {
-			return Scissors
-		} else if opponent == Paper {
-			return Rock
-		} else {
-			return Paper
-		}
-	} else if expectedResult == Draw {
-		return opponent
-	} else {
-		if opponent == Rock {
-			return Paper
-		} else if opponent == Paper {
-			return Scissors
-		} else {
-			return Rock
-		}
-	}
+func convertByteToExpectedResult(expectedResultByte byte) ExpectedResult {
+	return ExpectedResult(strings.IndexByte(expectedResultSymbols, expectedResultByte))
 }
 
 // X means you need to lose, Y means you need to end the round in a draw, and Z means you need to win
@@ -131,29 +93,32 @@ func getYourHand(expectedResult ExpectedResult, opponent Hand) Hand {
 // X for Rock, Y for Paper, and Z for Scissors
 // 1 for Rock, 2 for Paper, and 3 for Scissors
 // 0 if you lost, 3 if the round was a draw, and 6 if you won
-func day2_part2() {
-	rows, err := getRowsFromFile("input2.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-
+func day2_part2(lines *LineScanner) (Answer, error) {
+	game := day2Game
 	solution := 0
-	for _, row := range rows {
-		if row == "" {
-			continue
-		}
-		if len(row) != 3 {
-			log.Fatal("Invalid input: ", row)
-		}
-		var opponent Hand = convertByteToHand(row[0])
-		var expectedResult ExpectedResult = convertByteToExpectedResult(row[2])
-		var you Hand = getYourHand(expectedResult, opponent)
+	err := forEachStrategyGuideRow(lines, game.opponentSymbols, expectedResultSymbols, func(row StrategyGuideRow) {
+		var opponent Hand = game.getOpponentHand(row.opponent)
+		var expectedResult ExpectedResult = convertByteToExpectedResult(row.response)
+		var you Hand = game.getYourHand(expectedResult, opponent)
 
-		currentWinnerPoints := getWinnerPoints(opponent, you)
-		currentHandPoints := getHandPoints(you)
+		currentWinnerPoints := game.getWinnerPoints(opponent, you)
+		currentHandPoints := game.getHandPoints(you)
 		currentRoundPoints := currentWinnerPoints + currentHandPoints
 		solution += currentRoundPoints
+	})
+	if err != nil {
+		return Answer{}, err
 	}
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return newIntAnswer(solution), nil
+}
+
+// lintDay2 checks the rows of part 1, the response column of part 2 only
+// allows X, Y and Z.
+func lintDay2(input *PuzzleInput) []error {
+	game := day2Game
+	return lintRows(input.Lines(), func(line int, row string) error {
+		_, err := getStrategyGuideRowFromString(line, row, game.opponentSymbols, game.responseSymbols)
+		return err
+	})
 }
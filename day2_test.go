@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// the score of every round, written out by hand from the puzzle text
+var day2_part1_scores = map[string]int{
+	"A X": 1 + 3, "A Y": 2 + 6, "A Z": 3 + 0,
+	"B X": 1 + 0, "B Y": 2 + 3, "B Z": 3 + 6,
+	"C X": 1 + 6, "C Y": 2 + 0, "C Z": 3 + 3,
+}
+
+var day2_part2_scores = map[string]int{
+	"A X": 3 + 0, "A Y": 1 + 3, "A Z": 2 + 6,
+	"B X": 1 + 0, "B Y": 2 + 3, "B Z": 3 + 6,
+	"C X": 2 + 0, "C Y": 3 + 3, "C Z": 1 + 6,
+}
+
+func sumDay2Scores(input *PuzzleInput, scores map[string]int) (string, error) {
+	sum := 0
+	for _, row := range input.Lines() {
+		score, ok := scores[row]
+		if !ok {
+			return "", fmt.Errorf("not a round: %q", row)
+		}
+		sum += score
+	}
+	return strconv.Itoa(sum), nil
+}
+
+func day2_part1_naive(input *PuzzleInput) (string, error) {
+	return sumDay2Scores(input, day2_part1_scores)
+}
+
+func day2_part2_naive(input *PuzzleInput) (string, error) {
+	return sumDay2Scores(input, day2_part2_scores)
+}
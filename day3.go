@@ -1,9 +1,6 @@
-
 package main
 
 import (
-	"fmt"
-	"log"
 	"unicode"
 )
 
@@ -17,14 +14,35 @@ func getSymbolsBits(row string) uint64 {
 	return symbolsBit
 }
 
-func day3_part1() {
-	rows, err := getRowsFromFile("input3.txt")
-	if err != nil {
-		log.Fatal(err)
+func checkRucksackFromString(line int, row string) error {
+	if row == "" {
+		return newParseError(line, 1, row, "", "a rucksack")
+	}
+	for jdx, item := range row {
+		if !('a' <= item && item <= 'z') && !('A' <= item && item <= 'Z') {
+			return newParseError(line, jdx+1, row, string(item), "an item between a-z or A-Z")
+		}
+	}
+	if len(row)%2 != 0 {
+		return newParseError(line, len(row)+1, row, "", "as many items in both compartments")
 	}
+	return nil
+}
+
+func forEachRucksack(lines *LineScanner, visit func(row string)) error {
+	for lines.Scan() {
+		row := lines.Text()
+		if err := checkRucksackFromString(lines.Line(), row); err != nil {
+			return err
+		}
+		visit(row)
+	}
+	return lines.Err()
+}
 
+func day3_part1(lines *LineScanner) (Answer, error) {
 	solution := 0
-	for _, row := range rows {
+	err := forEachRucksack(lines, func(row string) {
 		var middle int = len(row) / 2
 		firstHalfOfRow := row[0:middle]
 		secondHalfOfRow := row[middle:]
@@ -47,23 +65,30 @@ func day3_part1() {
 				break
 			}
 		}
+	})
+	if err != nil {
+		return Answer{}, err
 	}
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return newIntAnswer(solution), nil
 }
 
-func day3_part2() {
-	rows, err := getRowsFromFile("input3.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-
+func day3_part2(lines *LineScanner) (Answer, error) {
 	solution := 0
 
-	for i := 2; i < len(rows); i += 3 {
-		firstElf := rows[i-2]
-		secondElf := rows[i-1]
-		thirdElf := rows[i]
+	var group [3]string
+	groupSize := 0
+	err := forEachRucksack(lines, func(row string) {
+		group[groupSize] = row
+		groupSize++
+		if groupSize < len(group) {
+			return
+		}
+		groupSize = 0
+
+		firstElf := group[0]
+		secondElf := group[1]
+		thirdElf := group[2]
 
 		var firstAndSecondSymbolsBit uint64 = getSymbolsBits(firstElf) & getSymbolsBits(secondElf)
 
@@ -81,7 +106,20 @@ func day3_part2() {
 				break
 			}
 		}
+	})
+	if err != nil {
+		return Answer{}, err
 	}
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return newIntAnswer(solution), nil
+}
+
+func lintDay3(input *PuzzleInput) []error {
+	rows := input.Lines()
+	errs := lintRows(rows, checkRucksackFromString)
+	if len(rows)%3 != 0 {
+		lastRow := rows[len(rows)-1]
+		errs = append(errs, newParseError(len(rows), len(lastRow)+1, lastRow, "", "groups of three rucksacks"))
+	}
+	return errs
 }
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func getItemPriority(item rune) int {
+	return strings.IndexRune(itemLetters, item) + 1
+}
+
+// findCommonItems checks every item of the first row against the others.
+func findCommonItems(rows ...string) []rune {
+	common := []rune{}
+	for _, item := range rows[0] {
+		inAll := true
+		for _, row := range rows[1:] {
+			inAll = inAll && strings.ContainsRune(row, item)
+		}
+		if inAll && !strings.ContainsRune(string(common), item) {
+			common = append(common, item)
+		}
+	}
+	return common
+}
+
+func sumCommonItems(groups [][]string) (string, error) {
+	sum := 0
+	for _, group := range groups {
+		common := findCommonItems(group...)
+		if len(common) != 1 || getItemPriority(common[0]) == 0 {
+			return "", fmt.Errorf("%q share %q instead of a single item", group, string(common))
+		}
+		sum += getItemPriority(common[0])
+	}
+	return strconv.Itoa(sum), nil
+}
+
+func day3_part1_naive(input *PuzzleInput) (string, error) {
+	groups := [][]string{}
+	for _, row := range input.Lines() {
+		if len(row)%2 != 0 {
+			return "", fmt.Errorf("odd rucksack %q", row)
+		}
+		groups = append(groups, []string{row[:len(row)/2], row[len(row)/2:]})
+	}
+	return sumCommonItems(groups)
+}
+
+func day3_part2_naive(input *PuzzleInput) (string, error) {
+	rows := input.Lines()
+	if len(rows)%3 != 0 {
+		return "", fmt.Errorf("%d rucksacks do not make groups of three", len(rows))
+	}
+	groups := [][]string{}
+	for idx := 0; idx < len(rows); idx += 3 {
+		groups = append(groups, rows[idx:idx+3])
+	}
+	return sumCommonItems(groups)
+}
@@ -1,9 +1,7 @@
-
 package main
 
 import (
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 )
@@ -24,51 +22,96 @@ func (lhs *Ranges) isIntersecting(rhs *Ranges) bool {
 		rhs.min <= lhs.max && lhs.max <= rhs.max
 }
 
-func getRangeFromString(rangeString string) *Ranges {
+func getRangeFromString(rangeString string) (*Ranges, error) {
 	ranges := strings.Split(rangeString, "-")
-	min, _ := strconv.ParseUint(ranges[0], 10, 32)
-	max, _ := strconv.ParseUint(ranges[1], 10, 32)
-	return &Ranges{uint(min), uint(max)}
+	if len(ranges) != 2 {
+		return nil, fmt.Errorf("invalid range %q", rangeString)
+	}
+	min, err := strconv.ParseUint(ranges[0], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	max, err := strconv.ParseUint(ranges[1], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	return &Ranges{uint(min), uint(max)}, nil
 }
 
-func day4_part1() {
-	rows, err := getRowsFromFile("input4.txt")
+func (r *Ranges) String() string {
+	return fmt.Sprintf("%d-%d", r.min, r.max)
+}
+
+type RangesPair struct {
+	first  *Ranges
+	second *Ranges
+}
+
+func getRangesPairFromString(line int, row string) (RangesPair, error) {
+	ranges := strings.Split(row, ",")
+	if len(ranges) != 2 {
+		return RangesPair{}, newParseError(line, 1, row, row, "two ranges like \"2-4,6-8\"")
+	}
+	firstRange, err := getRangeFromString(ranges[0])
+	if err != nil {
+		return RangesPair{}, newParseError(line, 1, row, ranges[0], "a range like \"2-4\"")
+	}
+	secondRange, err := getRangeFromString(ranges[1])
 	if err != nil {
-		log.Fatal(err)
+		return RangesPair{}, newParseError(line, len(ranges[0])+2, row, ranges[1], "a range like \"6-8\"")
 	}
+	if firstRange.min > firstRange.max {
+		return RangesPair{}, newParseError(line, 1, row, ranges[0], "a range with the lowest section first")
+	}
+	if secondRange.min > secondRange.max {
+		return RangesPair{}, newParseError(line, len(ranges[0])+2, row, ranges[1], "a range with the lowest section first")
+	}
+	return RangesPair{firstRange, secondRange}, nil
+}
 
+// countRangesPairs streams the pairs and counts those matching the condition.
+func countRangesPairs(lines *LineScanner, condition func(firstRange *Ranges, secondRange *Ranges) bool) (int, error) {
 	solution := 0
 
-	for _, row := range rows[0 : len(rows)-1] {
-		ranges := strings.Split(row, ",")
-		firstRange := getRangeFromString(ranges[0])
-		secondRange := getRangeFromString(ranges[1])
+	for lines.Scan() {
+		pair, err := getRangesPairFromString(lines.Line(), lines.Text())
+		if err != nil {
+			return 0, err
+		}
 
-		if firstRange.isSubRangeOf(secondRange) || secondRange.isSubRangeOf(firstRange) {
+		if condition(pair.first, pair.second) {
 			solution += 1
 		}
 	}
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return solution, lines.Err()
 }
 
-func day4_part2() {
-	rows, err := getRowsFromFile("input4.txt")
+func day4_part1(lines *LineScanner) (Answer, error) {
+	solution, err := countRangesPairs(lines, func(firstRange *Ranges, secondRange *Ranges) bool {
+		return firstRange.isSubRangeOf(secondRange) || secondRange.isSubRangeOf(firstRange)
+	})
 	if err != nil {
-		log.Fatal(err)
+		return Answer{}, err
 	}
 
-	solution := 0
-
-	for _, row := range rows[0 : len(rows)-1] {
-		ranges := strings.Split(row, ",")
-		firstRange := getRangeFromString(ranges[0])
-		secondRange := getRangeFromString(ranges[1])
+	return newIntAnswer(solution), nil
+}
 
-		if firstRange.isIntersecting(secondRange) {
-			solution += 1
-		}
+func day4_part2(lines *LineScanner) (Answer, error) {
+	solution, err := countRangesPairs(lines, func(firstRange *Ranges, secondRange *Ranges) bool {
+		return firstRange.isIntersecting(secondRange)
+	})
+	if err != nil {
+		return Answer{}, err
 	}
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return newIntAnswer(solution), nil
+}
+
+func lintDay4(input *PuzzleInput) []error {
+	return lintRows(input.Lines(), func(line int, row string) error {
+		_, err := getRangesPairFromString(line, row)
+		return err
+	})
 }
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestIsIntersecting(t *testing.T) {
+	tests := []struct {
+		lhs  Ranges
+		rhs  Ranges
+		want bool
+	}{
+		{Ranges{2, 4}, Ranges{6, 8}, false},
+		{Ranges{2, 3}, Ranges{4, 5}, false},
+		{Ranges{5, 7}, Ranges{7, 9}, true},
+		{Ranges{2, 8}, Ranges{3, 7}, true},
+		{Ranges{3, 7}, Ranges{2, 8}, true},
+		{Ranges{6, 6}, Ranges{4, 6}, true},
+		{Ranges{2, 6}, Ranges{4, 8}, true},
+		{Ranges{4, 8}, Ranges{2, 6}, true},
+		{Ranges{1, 1}, Ranges{2, 2}, false},
+	}
+
+	for _, test := range tests {
+		if got := test.lhs.isIntersecting(&test.rhs); got != test.want {
+			t.Errorf("%v.isIntersecting(%v) = %v, want %v", test.lhs, test.rhs, got, test.want)
+		}
+	}
+}
+
+func FuzzGetRangeFromString(f *testing.F) {
+	for _, seed := range []string{"2-4", "6-8", "0-0", "4294967295-1", "-", "1-2-3", "+1-07"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, rangeString string) {
+		ranges, err := getRangeFromString(rangeString)
+		if err != nil {
+			return
+		}
+		reparsed, err := getRangeFromString(ranges.String())
+		if err != nil {
+			t.Fatalf("getRangeFromString(%q) failed on its own output: %v", ranges.String(), err)
+		}
+		if *reparsed != *ranges {
+			t.Errorf("round trip of %q gave %v, want %v", rangeString, reparsed, ranges)
+		}
+	})
+}
+
+// countPairsOfSections writes out every section of both ranges and counts the
+// pairs for which condition holds on the two sets.
+func countPairsOfSections(input *PuzzleInput, condition func(first, second map[int]bool) bool) (string, error) {
+	count := 0
+	for _, row := range input.Lines() {
+		var bounds [4]int
+		if _, err := fmt.Sscanf(row, "%d-%d,%d-%d", &bounds[0], &bounds[1], &bounds[2], &bounds[3]); err != nil {
+			return "", err
+		}
+		if bounds[0] > bounds[1] || bounds[2] > bounds[3] || bounds[0] < 0 || bounds[2] < 0 {
+			return "", fmt.Errorf("not two ranges: %q", row)
+		}
+		first, second := map[int]bool{}, map[int]bool{}
+		for section := bounds[0]; section <= bounds[1]; section++ {
+			first[section] = true
+		}
+		for section := bounds[2]; section <= bounds[3]; section++ {
+			second[section] = true
+		}
+		if condition(first, second) {
+			count++
+		}
+	}
+	return strconv.Itoa(count), nil
+}
+
+func isSubsetOfSections(lhs, rhs map[int]bool) bool {
+	for section := range lhs {
+		if !rhs[section] {
+			return false
+		}
+	}
+	return true
+}
+
+func day4_part1_naive(input *PuzzleInput) (string, error) {
+	return countPairsOfSections(input, func(first, second map[int]bool) bool {
+		return isSubsetOfSections(first, second) || isSubsetOfSections(second, first)
+	})
+}
+
+func day4_part2_naive(input *PuzzleInput) (string, error) {
+	return countPairsOfSections(input, func(first, second map[int]bool) bool {
+		for section := range first {
+			if second[section] {
+				return true
+			}
+		}
+		return false
+	})
+}
@@ -2,40 +2,94 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
-func findEmptyRowIndex(rows []string) int {
-	for i, row := range rows {
-		if row == "" {
-			return i
+// getMaxStackNumber reads the row numbering the stacks, which has to count
+// up from 1.
+func getMaxStackNumber(line int, row string) (int, error) {
+	stackNumbers := strings.Fields(row)
+	if len(stackNumbers) == 0 {
+		return 0, newParseError(line, 1, row, row, "the stack numbers")
+	}
+	column := 0
+	for idx, stackNumber := range stackNumbers {
+		column += strings.Index(row[column:], stackNumber)
+		if stackNumber != strconv.Itoa(idx+1) {
+			return 0, newParseError(line, column+1, row, stackNumber, fmt.Sprintf("stack number %d", idx+1))
 		}
+		column += len(stackNumber)
 	}
-	return -1
+	return len(stackNumbers), nil
 }
 
-func getMaxStackNumber(row string) int {
-	maxNumber := len(row)/4 + 1
-	return maxNumber
+func getStacksArrayFromRows(rows []string) ([][]byte, error) {
+	stackArray, errs := readStacksArray(rows)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return stackArray, nil
 }
 
-func getStacksArrayFromRows(rows []string) [][]byte {
-	highestStackNumber := getMaxStackNumber(rows[len(rows)-1])
+// readStacksArray reads the drawing and returns every crate that is not above
+// a numbered stack, the drawing can only be read when the numbers are fine.
+func readStacksArray(rows []string) ([][]byte, []error) {
+	if len(rows) == 0 {
+		return nil, []error{newParseError(1, 1, "", "", "a drawing of the stacks")}
+	}
+	highestStackNumber, err := getMaxStackNumber(len(rows), rows[len(rows)-1])
+	if err != nil {
+		return nil, []error{err}
+	}
 	stackArray := make([][]byte, highestStackNumber)
 
+	var errs []error
 	for i := len(rows) - 2; i >= 0; i-- {
-		row := rows[i][1 : len(rows[i])-1] // removing trailing and leading non-stack symbols
-		for j := 0; j < len(row); j += 4 {
+		row := rows[i]
+		// the crate symbols sit at every fourth column, after the leading '['
+		for j := 1; j < len(row); j += 4 {
 			if row[j] != ' ' {
 				var stackIndex = j / 4
+				if stackIndex >= highestStackNumber {
+					errs = append(errs, newParseError(i+1, j+1, row, row[j:j+1], "a crate above a numbered stack"))
+					continue
+				}
 				stackArray[stackIndex] = append(stackArray[stackIndex], row[j])
 			}
 		}
 	}
-	return stackArray
+	return stackArray, errs
+}
+
+// formatStacksArray draws the stacks the same way as the puzzle input.
+func formatStacksArray(stackArray [][]byte) []string {
+	maxLength := 0
+	for _, stack := range stackArray {
+		if len(stack) > maxLength {
+			maxLength = len(stack)
+		}
+	}
+
+	rows := make([]string, 0, maxLength+1)
+	for height := maxLength - 1; height >= 0; height-- {
+		row := make([]string, len(stackArray))
+		for idx, stack := range stackArray {
+			if height < len(stack) {
+				row[idx] = "[" + string(stack[height:height+1]) + "]"
+			} else {
+				row[idx] = "   "
+			}
+		}
+		rows = append(rows, strings.Join(row, " "))
+	}
+
+	stackNumbers := make([]string, len(stackArray))
+	for idx := range stackArray {
+		stackNumbers[idx] = fmt.Sprintf("%-3s", " "+strconv.Itoa(idx+1))
+	}
+	return append(rows, strings.Join(stackNumbers, " "))
 }
 
 func transposeStackArray(stackArray [][]byte) [][]byte {
@@ -77,125 +131,199 @@ func printStacksArray(stackArray [][]byte) {
 	}
 }
 
-func convertStringToInt(s string) int {
-	i, err := strconv.Atoi(s)
-	if err != nil {
-		log.Fatal(err)
-	}
-	return i
+// CrateMove keeps its line and row, so a move taking more crates than the
+// stack holds can be reported where it was written.
+type CrateMove struct {
+	nrToMove       int
+	fromStackIndex int
+	toStackIndex   int
+	line           int
+	row            string
 }
 
-func moveStacksViaInstructionsOneAtTheTime(stackArray [][]byte, instructionRows []string) [][]byte {
-	for _, row := range instructionRows {
-		re := regexp.MustCompile(`move (\d+) from (\d+) to (\d+)`)
-		matches := re.FindStringSubmatch(row)
-		if len(matches) != 4 {
-			log.Fatal("Invalid instruction row: ", row)
+func (m CrateMove) newTooManyCratesError(height int) error {
+	return newParseError(m.line, len("move ")+1, m.row, strconv.Itoa(m.nrToMove), fmt.Sprintf("at most the %d crates on stack %d", height, m.fromStackIndex+1))
+}
+
+var crateMoveRegexp = regexp.MustCompile(`^move (\d+) from (\d+) to (\d+)$`)
+
+func getCrateMovesFromRows(instructionRows []string, firstLine int, nrOfStacks int) ([]CrateMove, error) {
+	moves := make([]CrateMove, 0, len(instructionRows))
+	for idx, row := range instructionRows {
+		line := firstLine + idx
+		matches := crateMoveRegexp.FindStringSubmatchIndex(row)
+		if matches == nil {
+			return nil, newParseError(line, 1, row, row, "\"move N from N to N\"")
 		}
 
-		nrToMove := convertStringToInt(matches[1])
-		fromStackIndex := convertStringToInt(matches[2]) - 1
-		toStackIndex := convertStringToInt(matches[3]) - 1
+		var values [3]int
+		for group := 1; group <= 3; group++ {
+			start, end := matches[2*group], matches[2*group+1]
+			value, err := strconv.Atoi(row[start:end])
+			if err != nil {
+				return nil, newParseError(line, start+1, row, row[start:end], "a number")
+			}
+			if group > 1 && (value < 1 || value > nrOfStacks) {
+				return nil, newParseError(line, start+1, row, row[start:end], fmt.Sprintf("a stack between 1 and %d", nrOfStacks))
+			}
+			values[group-1] = value
+		}
+
+		moves = append(moves, CrateMove{nrToMove: values[0], fromStackIndex: values[1] - 1, toStackIndex: values[2] - 1, line: line, row: row})
+	}
+	return moves, nil
+}
 
-		fromStack := stackArray[fromStackIndex]
-		if len(fromStack) < nrToMove {
-			log.Fatal("Trying to move more than there is in stack: ", row)
+func moveStacksViaInstructionsOneAtTheTime(stackArray [][]byte, moves []CrateMove) ([][]byte, error) {
+	for _, move := range moves {
+		fromStack := stackArray[move.fromStackIndex]
+		if len(fromStack) < move.nrToMove {
+			return nil, move.newTooManyCratesError(len(fromStack))
 		}
 
-		toStack := stackArray[toStackIndex]
-		for i := 0; i < nrToMove; i++ {
+		toStack := stackArray[move.toStackIndex]
+		for i := 0; i < move.nrToMove; i++ {
 			toStack = append(toStack, fromStack[len(fromStack)-1])
 			fromStack = fromStack[:len(fromStack)-1]
 		}
 
-		stackArray[fromStackIndex] = fromStack
-		stackArray[toStackIndex] = toStack
+		stackArray[move.fromStackIndex] = fromStack
+		stackArray[move.toStackIndex] = toStack
 
-		// fmt.Println("Moving ", nrToMove, " from ", fromStackIndex, " to ", toStackIndex)
+		// fmt.Println("Moving ", move.nrToMove, " from ", move.fromStackIndex, " to ", move.toStackIndex)
 		// fmt.Println("stacksArray state: ")
 		// printStacksArray(stackArray)
 	}
 
-	return stackArray
+	return stackArray, nil
 }
 
-func day5_part1() {
-	rows, err := getRowsFromFile("input5.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
+type CratePlan struct {
+	stacksArray [][]byte
+	moves       []CrateMove
+}
 
-	dividingRow := findEmptyRowIndex(rows)
-	stacksArray := getStacksArrayFromRows(rows[0:dividingRow])
-	// fmt.Println("stacksArray start state: ")
-	// printStacksArray(stacksArray)
+func parseDay5(input *PuzzleInput) (*CratePlan, error) {
+	paragraphs := input.Paragraphs()
+	if len(paragraphs) == 0 {
+		return nil, newParseError(1, 1, "", "", "a drawing of the stacks")
+	}
+	if len(paragraphs) > 2 {
+		return nil, newParseError(paragraphs[2].line, 1, paragraphs[2].rows[0], paragraphs[2].rows[0], "the end of the instructions")
+	}
 
-	finishedStacksArray := moveStacksViaInstructionsOneAtTheTime(stacksArray, rows[dividingRow+1:len(rows)-1])
-	// fmt.Println("stacksArVdray end state: ")
-	// printStacksArray(finishedStacksArray)
+	stacksArray, err := getStacksArrayFromRows(paragraphs[0].rows)
+	if err != nil {
+		return nil, err
+	}
+	moves := []CrateMove{}
+	if len(paragraphs) == 2 {
+		moves, err = getCrateMovesFromRows(paragraphs[1].rows, paragraphs[1].line, len(stacksArray))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &CratePlan{stacksArray: stacksArray, moves: moves}, nil
+}
 
+func getTopOfStacks(stacksArray [][]byte) string {
 	solution := ""
 
-	for _, stack := range finishedStacksArray {
-		solution += string(stack[len(stack)-1])
+	for _, stack := range stacksArray {
+		if len(stack) > 0 {
+			solution += string(stack[len(stack)-1])
+		}
 	}
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return solution
 }
 
-func moveStacksViaInstructionsMultipleAtTheTime(stackArray [][]byte, instructionRows []string) [][]byte {
-	for _, row := range instructionRows {
-		re := regexp.MustCompile(`move (\d+) from (\d+) to (\d+)`)
-		matches := re.FindStringSubmatch(row)
-		if len(matches) != 4 {
-			log.Fatal("Invalid instruction row: ", row)
-		}
+func day5_part1(plan *CratePlan) (Answer, error) {
+	// fmt.Println("stacksArray start state: ")
+	// printStacksArray(plan.stacksArray)
+
+	finishedStacksArray, err := moveStacksViaInstructionsOneAtTheTime(plan.stacksArray, plan.moves)
+	if err != nil {
+		return Answer{}, err
+	}
+	// fmt.Println("stacksArVdray end state: ")
+	// printStacksArray(finishedStacksArray)
 
-		nrToMove := convertStringToInt(matches[1])
-		fromStackIndex := convertStringToInt(matches[2]) - 1
-		toStackIndex := convertStringToInt(matches[3]) - 1
+	return newStringAnswer(getTopOfStacks(finishedStacksArray)), nil
+}
 
-		fromStack := stackArray[fromStackIndex]
-		if len(fromStack) < nrToMove {
-			log.Fatal("Trying to move more than there is in stack: ", row)
+func moveStacksViaInstructionsMultipleAtTheTime(stackArray [][]byte, moves []CrateMove) ([][]byte, error) {
+	for _, move := range moves {
+		fromStack := stackArray[move.fromStackIndex]
+		if len(fromStack) < move.nrToMove {
+			return nil, move.newTooManyCratesError(len(fromStack))
 		}
 
-		toStack := stackArray[toStackIndex]
+		toStack := stackArray[move.toStackIndex]
 
-		toStack = append(toStack, fromStack[len(fromStack)-nrToMove:]...)
-		fromStack = fromStack[:len(fromStack)-nrToMove]
+		toStack = append(toStack, fromStack[len(fromStack)-move.nrToMove:]...)
+		fromStack = fromStack[:len(fromStack)-move.nrToMove]
 
-		stackArray[fromStackIndex] = fromStack
-		stackArray[toStackIndex] = toStack
+		stackArray[move.fromStackIndex] = fromStack
+		stackArray[move.toStackIndex] = toStack
 
-		// fmt.Println("Moving ", nrToMove, " from ", fromStackIndex, " to ", toStackIndex)
+		// fmt.Println("Moving ", move.nrToMove, " from ", move.fromStackIndex, " to ", move.toStackIndex)
 		// fmt.Println("stacksArray state: ")
 		// printStacksArray(stackArray)
 	}
 
-	return stackArray
+	return stackArray, nil
 }
 
-func day5_part2() {
-	rows, err := getRowsFromFile("input5.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	dividingRow := findEmptyRowIndex(rows)
-	stacksArray := getStacksArrayFromRows(rows[0:dividingRow])
+func day5_part2(plan *CratePlan) (Answer, error) {
 	// fmt.Println("stacksArray start state: ")
-	// printStacksArray(stacksArray)
+	// printStacksArray(plan.stacksArray)
 
-	finishedStacksArray := moveStacksViaInstructionsMultipleAtTheTime(stacksArray, rows[dividingRow+1:len(rows)-1])
+	finishedStacksArray, err := moveStacksViaInstructionsMultipleAtTheTime(plan.stacksArray, plan.moves)
+	if err != nil {
+		return Answer{}, err
+	}
 	// fmt.Println("stacksArVdray end state: ")
 	// printStacksArray(finishedStacksArray)
 
-	solution := ""
+	return newStringAnswer(getTopOfStacks(finishedStacksArray)), nil
+}
 
-	for _, stack := range finishedStacksArray {
-		solution += string(stack[len(stack)-1])
+func lintDay5(input *PuzzleInput) []error {
+	paragraphs := input.Paragraphs()
+	if len(paragraphs) == 0 {
+		return []error{newParseError(1, 1, "", "", "a drawing of the stacks")}
 	}
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	stacksArray, errs := readStacksArray(paragraphs[0].rows)
+	if len(paragraphs) > 2 {
+		for _, paragraph := range paragraphs[2:] {
+			errs = append(errs, newParseError(paragraph.line, 1, paragraph.rows[0], paragraph.rows[0], "the end of the instructions"))
+		}
+	}
+	if stacksArray == nil || len(paragraphs) < 2 {
+		return errs
+	}
+
+	// play the moves to find those taking more crates than the stack holds
+	heights := make([]int, len(stacksArray))
+	for idx, stack := range stacksArray {
+		heights[idx] = len(stack)
+	}
+	for idx, row := range paragraphs[1].rows {
+		line := paragraphs[1].line + idx
+		moves, err := getCrateMovesFromRows([]string{row}, line, len(stacksArray))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		move := moves[0]
+		if heights[move.fromStackIndex] < move.nrToMove {
+			errs = append(errs, move.newTooManyCratesError(heights[move.fromStackIndex]))
+			continue
+		}
+		heights[move.fromStackIndex] -= move.nrToMove
+		heights[move.toStackIndex] += move.nrToMove
+	}
+	return errs
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func FuzzGetStacksArrayFromRows(f *testing.F) {
+	f.Add(strings.Join(newPuzzleInput([]byte(readExample(f, "day5.txt"))).Paragraphs()[0].rows, "\n"))
+	f.Add(" 1 ")
+	f.Add("[A]\n 1   2 ")
+	f.Add("        [B]\n 1 ")
+
+	f.Fuzz(func(t *testing.T, drawing string) {
+		stacksArray, err := getStacksArrayFromRows(strings.Split(drawing, "\n"))
+		if err != nil {
+			return
+		}
+		reparsed, err := getStacksArrayFromRows(formatStacksArray(stacksArray))
+		if err != nil {
+			t.Fatalf("getStacksArrayFromRows failed on its own output: %v", err)
+		}
+		if !reflect.DeepEqual(reparsed, stacksArray) {
+			t.Errorf("round trip of %q gave %q, want %q", drawing, reparsed, stacksArray)
+		}
+	})
+}
+
+// moveCratesOneByOne reads the drawing column by column and moves the crates
+// one at the time. Moving several crates at once goes through a temporary
+// stack, which keeps their order.
+func moveCratesOneByOne(input *PuzzleInput, keepOrder bool) (string, error) {
+	paragraphs := input.Paragraphs()
+	if len(paragraphs) != 2 {
+		return "", fmt.Errorf("expected a drawing and moves, got %d paragraphs", len(paragraphs))
+	}
+	drawing := paragraphs[0].rows
+	nrOfStacks := len(strings.Fields(drawing[len(drawing)-1]))
+	for idx, number := range strings.Fields(drawing[len(drawing)-1]) {
+		if number != strconv.Itoa(idx+1) {
+			return "", fmt.Errorf("not a stack number: %q", number)
+		}
+	}
+
+	stacks := make([][]byte, nrOfStacks)
+	for i := len(drawing) - 2; i >= 0; i-- {
+		row := drawing[i]
+		if len(row) > 4*nrOfStacks-1 {
+			return "", fmt.Errorf("crate outside the stacks: %q", row)
+		}
+		for stack := 0; stack < nrOfStacks; stack++ {
+			if column := 1 + 4*stack; column < len(row) && row[column] != ' ' {
+				stacks[stack] = append(stacks[stack], row[column])
+			}
+		}
+	}
+
+	for _, row := range paragraphs[1].rows {
+		var nrToMove, from, to int
+		if _, err := fmt.Sscanf(row, "move %d from %d to %d", &nrToMove, &from, &to); err != nil {
+			return "", err
+		}
+		if from < 1 || from > nrOfStacks || to < 1 || to > nrOfStacks || nrToMove < 0 || nrToMove > len(stacks[from-1]) {
+			return "", fmt.Errorf("not a valid move: %q", row)
+		}
+
+		moving := []byte{}
+		for i := 0; i < nrToMove; i++ {
+			moving = append(moving, stacks[from-1][len(stacks[from-1])-1])
+			stacks[from-1] = stacks[from-1][:len(stacks[from-1])-1]
+		}
+		for i := 0; i < nrToMove; i++ {
+			crate := moving[i]
+			if keepOrder {
+				crate = moving[nrToMove-1-i]
+			}
+			stacks[to-1] = append(stacks[to-1], crate)
+		}
+	}
+
+	tops := ""
+	for _, stack := range stacks {
+		if len(stack) > 0 {
+			tops += string(stack[len(stack)-1])
+		}
+	}
+	return tops, nil
+}
+
+func day5_part1_naive(input *PuzzleInput) (string, error) {
+	return moveCratesOneByOne(input, false)
+}
+
+func day5_part2_naive(input *PuzzleInput) (string, error) {
+	return moveCratesOneByOne(input, true)
+}
+
+func TestMoveTooManyCrates(t *testing.T) {
+	input := "    [D]    \n[N] [C]    \n[Z] [M] [P]\n 1   2   3 \n\nmove 1 from 2 to 1\nmove 5 from 1 to 3"
+	for part, solve := range map[int]func(plan *CratePlan) (Answer, error){1: day5_part1, 2: day5_part2} {
+		plan, err := parseDay5(newPuzzleInput([]byte(input)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = solve(plan)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.line != 7 || parseErr.column != 6 {
+			t.Errorf("part %d: got %v, want a parse error at 7:6", part, err)
+		}
+	}
+}
@@ -1,11 +1,5 @@
-
 package main
 
-import (
-	"fmt"
-	"log"
-)
-
 func getIndexOfFirstUniqueSequence(nrOfUnique int, sequence string) int {
 	i := nrOfUnique - 1
 	for {
@@ -32,28 +26,37 @@ func getIndexOfFirstUniqueSequence(nrOfUnique int, sequence string) int {
 	return -1
 }
 
-func day6_part1() {
-	rows, err := getRowsFromFile("input6.txt")
-	if err != nil {
-		log.Fatal(err)
+func lintDay6(input *PuzzleInput) []error {
+	datastreams := input.Lines()
+	if len(datastreams) == 0 {
+		return []error{newParseError(1, 1, "", "", "a datastream row")}
 	}
 
-	for _, row := range rows[:len(rows)-1] {
-		solution := getIndexOfFirstUniqueSequence(4, row)
-
-		fmt.Println(getFunctionName(), " solution: ", solution)
+	var errs []error
+	for jdx, char := range datastreams[0] {
+		if char < 'a' || char > 'z' {
+			errs = append(errs, newParseError(1, jdx+1, datastreams[0], string(char), "a character between a-z"))
+		}
 	}
+	for idx, datastream := range datastreams[1:] {
+		errs = append(errs, newParseError(idx+2, 1, datastream, datastream, "a single datastream row"))
+	}
+	return errs
 }
 
-func day6_part2() {
-	rows, err := getRowsFromFile("input6.txt")
-	if err != nil {
-		log.Fatal(err)
+func parseDay6(input *PuzzleInput) (string, error) {
+	if errs := lintDay6(input); len(errs) > 0 {
+		return "", errs[0]
 	}
+	return input.Lines()[0], nil
+}
 
-	for _, row := range rows[:len(rows)-1] {
-		solution := getIndexOfFirstUniqueSequence(14, row)
+func day6_part1(datastream string) (Answer, error) {
+	solution := getIndexOfFirstUniqueSequence(4, datastream)
+	return newIntAnswer(solution), nil
+}
 
-		fmt.Println(getFunctionName(), " solution: ", solution)
-	}
+func day6_part2(datastream string) (Answer, error) {
+	solution := getIndexOfFirstUniqueSequence(14, datastream)
+	return newIntAnswer(solution), nil
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGetIndexOfFirstUniqueSequence(t *testing.T) {
+	tests := []struct {
+		sequence     string
+		startMarker  int
+		messageStart int
+	}{
+		{"mjqjpqmgbljsphdztnvjfqwrcgsmlb", 7, 19},
+		{"bvwbjplbgvbhsrlpgdmjqwftvncz", 5, 23},
+		{"nppdvjthqldpwncqszvftbrmjlhg", 6, 23},
+		{"nznrnfrfntjfmvfwmzdfjlvtqnbhcprsg", 10, 29},
+		{"zcfzfwzzqfrljwzlrfnpqdbhtmscgvjw", 11, 26},
+		{"aaaa", -1, -1},
+	}
+
+	for _, test := range tests {
+		if got := getIndexOfFirstUniqueSequence(4, test.sequence); got != test.startMarker {
+			t.Errorf("getIndexOfFirstUniqueSequence(4, %q) = %d, want %d", test.sequence, got, test.startMarker)
+		}
+		if got := getIndexOfFirstUniqueSequence(14, test.sequence); got != test.messageStart {
+			t.Errorf("getIndexOfFirstUniqueSequence(14, %q) = %d, want %d", test.sequence, got, test.messageStart)
+		}
+	}
+}
+
+func BenchmarkGetIndexOfFirstUniqueSequence(b *testing.B) {
+	sequence := string(readEmbeddedInput(b, 6))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		getIndexOfFirstUniqueSequence(14, sequence)
+	}
+}
+
+// findMarkerInWindows compares every pair in every window.
+func findMarkerInWindows(datastream string, length int) int {
+	for end := length; end <= len(datastream); end++ {
+		unique := true
+		for i := end - length; i < end; i++ {
+			for j := i + 1; j < end; j++ {
+				unique = unique && datastream[i] != datastream[j]
+			}
+		}
+		if unique {
+			return end
+		}
+	}
+	return -1
+}
+
+func getDatastream(input *PuzzleInput) (string, error) {
+	lines := input.Lines()
+	if len(lines) != 1 || strings.Trim(lines[0], lowercaseLetters) != "" {
+		return "", fmt.Errorf("not a single datastream of lowercase letters")
+	}
+	return lines[0], nil
+}
+
+func day6_part1_naive(input *PuzzleInput) (string, error) {
+	datastream, err := getDatastream(input)
+	return strconv.Itoa(findMarkerInWindows(datastream, 4)), err
+}
+
+func day6_part2_naive(input *PuzzleInput) (string, error) {
+	datastream, err := getDatastream(input)
+	return strconv.Itoa(findMarkerInWindows(datastream, 14)), err
+}
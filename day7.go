@@ -1,10 +1,8 @@
-
 package main
 
 import (
 	"errors"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 )
@@ -22,14 +20,17 @@ type Directory struct {
 	totalSize int
 }
 
-func cdCommand(rootDirectory *Directory, currentDirectory *Directory, cmd []string) (*Directory, error) {
-	if cmd[2] == ".." {
+func cdCommand(rootDirectory *Directory, currentDirectory *Directory, name string) (*Directory, error) {
+	if name == ".." {
+		if currentDirectory.parent == nil {
+			return nil, errors.New("already at the root directory")
+		}
 		return currentDirectory.parent, nil
-	} else if cmd[2] == "/" {
+	} else if name == "/" {
 		return rootDirectory, nil
 	} else {
 		for _, child := range currentDirectory.children {
-			if child.name == cmd[2] {
+			if child.name == name {
 				return child, nil
 			}
 		}
@@ -46,63 +47,113 @@ func doesDirectoryExist(directory *Directory, name string) bool {
 	return false
 }
 
-func parseDirectoryFromStrings(fileLines []string) *Directory {
-	root := Directory{name: "/", parent: nil, children: []*Directory{}, files: []File{}}
-	currentDirectory := &root
-	for _, row := range fileLines {
-		if row == "" {
-			continue
-		}
+// Terminal replays the commands and their output, a row that can not be
+// read leaves it as it was.
+type Terminal struct {
+	root             *Directory
+	currentDirectory *Directory
+}
+
+func newTerminal() *Terminal {
+	root := &Directory{name: "/", parent: nil, children: []*Directory{}, files: []File{}}
+	return &Terminal{root: root, currentDirectory: root}
+}
 
-		parsedRow := strings.Split(row, " ")
-		if parsedRow[0] == "$" { // checks for command
-			if parsedRow[1] == "cd" {
-				var err error
-				currentDirectory, err = cdCommand(&root, currentDirectory, parsedRow)
-				if err != nil {
-					log.Fatal(err)
-				}
+func (t *Terminal) readRow(line int, row string) error {
+	if row == "" {
+		return nil
+	}
+
+	parsedRow := strings.Split(row, " ")
+	if parsedRow[0] == "$" { // checks for command
+		if len(parsedRow) < 2 {
+			return newParseError(line, len(row)+1, row, "", "a command")
+		}
+		if parsedRow[1] == "cd" {
+			if len(parsedRow) != 3 {
+				return newFieldParseError(line, row, parsedRow, 1, "\"cd <directory>\"")
+			}
+			nextDirectory, err := cdCommand(t.root, t.currentDirectory, parsedRow[2])
+			if err != nil {
+				return newFieldParseError(line, row, parsedRow, 2, "a directory listed in "+t.currentDirectory.name)
+			}
+			t.currentDirectory = nextDirectory
+		} else if parsedRow[1] != "ls" || len(parsedRow) != 2 {
+			return newFieldParseError(line, row, parsedRow, 1, "\"cd <directory>\" or \"ls\"")
+		}
+	} else {
+		if len(parsedRow) != 2 {
+			return newParseError(line, 1, row, row, "\"dir <name>\" or \"<size> <name>\"")
+		}
+		if parsedRow[0] == "dir" {
+			if parsedRow[1] == "/" || parsedRow[1] == ".." {
+				return newFieldParseError(line, row, parsedRow, 1, "a directory name other than / or ..")
+			}
+			// a directory listed again is already known
+			if !doesDirectoryExist(t.currentDirectory, parsedRow[1]) {
+				t.currentDirectory.children = append(t.currentDirectory.children, &Directory{name: parsedRow[1], parent: t.currentDirectory, children: []*Directory{}, files: []File{}})
 			}
 		} else {
-			if parsedRow[0] == "dir" {
-				if !doesDirectoryExist(currentDirectory, parsedRow[1]) {
-					currentDirectory.children = append(currentDirectory.children, &Directory{name: parsedRow[1], parent: currentDirectory, children: []*Directory{}, files: []File{}})
-				} else {
-					fmt.Println("Directory ", parsedRow[1], " already exists")
-				}
-			} else {
-				currentDirectory.files = append(currentDirectory.files, File{name: parsedRow[1], size: getFileSizeFromString(parsedRow[0])})
+			size, err := getFileSizeFromString(parsedRow[0])
+			if err != nil {
+				return newFieldParseError(line, row, parsedRow, 0, "\"dir\" or a file size")
 			}
+			t.currentDirectory.files = append(t.currentDirectory.files, File{name: parsedRow[1], size: size})
 		}
 	}
-
-	return &root
+	return nil
 }
 
-func getFileSizeFromString(fileString string) int {
-	size, err := strconv.Atoi(fileString)
-	if err != nil {
-		log.Fatal(err)
+func parseDirectoryFromStrings(fileLines []string) (*Directory, error) {
+	terminal := newTerminal()
+	for idx, row := range fileLines {
+		if err := terminal.readRow(idx+1, row); err != nil {
+			return nil, err
+		}
 	}
-	return size
+
+	return terminal.root, nil
 }
 
-func hasVisitedDirectory(current *Directory, visited []*Directory) bool {
-	for _, directory := range visited {
-		if directory.name == current.name && directory.parent.name == current.parent.name {
-			return true
+func lintDay7(input *PuzzleInput) []error {
+	return lintRows(input.Lines(), newTerminal().readRow)
+}
+
+func getFileSizeFromString(fileString string) (int, error) {
+	return strconv.Atoi(fileString)
+}
+
+// formatDirectoryTree writes the terminal output that lists every directory
+// once, depth first.
+func formatDirectoryTree(root *Directory) []string {
+	rows := []string{"$ cd /"}
+	var listDirectory func(directory *Directory)
+	listDirectory = func(directory *Directory) {
+		rows = append(rows, "$ ls")
+		for _, child := range directory.children {
+			rows = append(rows, "dir "+child.name)
+		}
+		for _, file := range directory.files {
+			rows = append(rows, fmt.Sprintf("%d %s", file.size, file.name))
+		}
+		for _, child := range directory.children {
+			rows = append(rows, "$ cd "+child.name)
+			listDirectory(child)
+			rows = append(rows, "$ cd ..")
 		}
 	}
-	return false
+	listDirectory(root)
+	return rows
 }
 
-func isAllChildrenVisited(directory *Directory, visited []*Directory) bool {
+// forEachDirectory visits the directory and everything below it, depth
+// first. Directories are told apart by pointer, so directories with the same
+// name in different places are all visited.
+func forEachDirectory(directory *Directory, visit func(directory *Directory)) {
+	visit(directory)
 	for _, child := range directory.children {
-		if !hasVisitedDirectory(child, visited) {
-			return false
-		}
+		forEachDirectory(child, visit)
 	}
-	return true
 }
 
 func getFileSizeOfDirectory(directory *Directory) int {
@@ -123,84 +174,45 @@ func setTotalSizeToAllDirectories(directory *Directory) int {
 }
 
 func printDirectoryTree(directory *Directory) {
-	visitedDirectories := []*Directory{}
 	depth := 0
 	depthToPrint := 0
-	printDirectoryRecursive(directory, visitedDirectories, depth, depthToPrint)
+	printDirectoryRecursive(directory, depth, depthToPrint)
 }
 
-func printDirectoryRecursive(directory *Directory, visitedDirectories []*Directory, depth int, depthToPrint int) {
+func printDirectoryRecursive(directory *Directory, depth int, depthToPrint int) {
 	indentation := strings.Repeat("  ", depth)
 
 	if depthToPrint == 0 || depth <= depthToPrint {
 		fmt.Printf("%sdir: %s size: [%d] depth: %d\n", indentation, directory.name, directory.totalSize, depth)
-		visitedDirectories = append(visitedDirectories, directory)
 	}
 	for _, child := range directory.children {
-		if !hasVisitedDirectory(child, visitedDirectories) {
-			printDirectoryRecursive(child, visitedDirectories, depth+1, depthToPrint)
-		}
+		printDirectoryRecursive(child, depth+1, depthToPrint)
 	}
 }
 
-func day7_part1() {
-	rows, err := getRowsFromFile("input7.txt")
+func parseDay7(input *PuzzleInput) (*Directory, error) {
+	root, err := parseDirectoryFromStrings(input.Lines())
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-
-	root := parseDirectoryFromStrings(rows)
 	setTotalSizeToAllDirectories(root)
+	return root, nil
+}
 
+func day7_part1(root *Directory) (Answer, error) {
 	maxSize := 100000
 	solution := 0
 
-	visitedDirectories := []*Directory{}
-	currentDirectory := root
-	for {
-		if len(currentDirectory.children) == 0 {
-			if currentDirectory.totalSize <= maxSize {
-				solution += currentDirectory.totalSize
-			}
-			visitedDirectories = append(visitedDirectories, currentDirectory)
-			if currentDirectory.parent == nil {
-				break
-			}
-			currentDirectory = currentDirectory.parent
-		} else {
-			if isAllChildrenVisited(currentDirectory, visitedDirectories) {
-				if currentDirectory.totalSize <= maxSize {
-					solution += currentDirectory.totalSize
-				}
-				visitedDirectories = append(visitedDirectories, currentDirectory)
-				if currentDirectory.parent == nil {
-					break
-				}
-				currentDirectory = currentDirectory.parent
-			} else {
-				for _, child := range currentDirectory.children {
-					if !hasVisitedDirectory(child, visitedDirectories) {
-						currentDirectory = child
-						break
-					}
-				}
-			}
+	forEachDirectory(root, func(directory *Directory) {
+		if directory.totalSize <= maxSize {
+			solution += directory.totalSize
 		}
-	}
+	})
 
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return newIntAnswer(solution), nil
 }
 
-func day7_part2() {
-	rows, err := getRowsFromFile("input7.txt")
-
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	root := parseDirectoryFromStrings(rows)
-	setTotalSizeToAllDirectories(root)
-
+func day7_part2(root *Directory) (Answer, error) {
 	fileSystemMaxSize := 70000000
 	neededSpace := 30000000
 	usedSpace := root.totalSize
@@ -209,43 +221,12 @@ func day7_part2() {
 	needToFree := totSize - fileSystemMaxSize
 
 	deleteCandidate := root
-	visitedDirectories := []*Directory{}
-	currentDirectory := root
-	for {
-		if len(currentDirectory.children) == 0 {
-			if currentDirectory.totalSize >= needToFree {
-				if currentDirectory.totalSize <= deleteCandidate.totalSize {
-					deleteCandidate = currentDirectory
-				}
-			}
-			visitedDirectories = append(visitedDirectories, currentDirectory)
-			if currentDirectory.parent == nil {
-				break
-			}
-			currentDirectory = currentDirectory.parent
-		} else {
-			if isAllChildrenVisited(currentDirectory, visitedDirectories) {
-				if currentDirectory.totalSize >= needToFree {
-					if currentDirectory.totalSize <= deleteCandidate.totalSize {
-						deleteCandidate = currentDirectory
-					}
-				}
-				visitedDirectories = append(visitedDirectories, currentDirectory)
-				if currentDirectory.parent == nil {
-					break
-				}
-				currentDirectory = currentDirectory.parent
-			} else {
-				for _, child := range currentDirectory.children {
-					if !hasVisitedDirectory(child, visitedDirectories) {
-						currentDirectory = child
-						break
-					}
-				}
-			}
+	forEachDirectory(root, func(directory *Directory) {
+		if directory.totalSize >= needToFree && directory.totalSize <= deleteCandidate.totalSize {
+			deleteCandidate = directory
 		}
-	}
+	})
 
 	solution := deleteCandidate.totalSize
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return newIntAnswer(solution), nil
 }
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func assertSameDirectory(t *testing.T, got *Directory, want *Directory) {
+	t.Helper()
+	if got.name != want.name {
+		t.Fatalf("directory %q, want %q", got.name, want.name)
+	}
+	if !reflect.DeepEqual(got.files, want.files) {
+		t.Fatalf("files of %q are %v, want %v", want.name, got.files, want.files)
+	}
+	if len(got.children) != len(want.children) {
+		t.Fatalf("%q has %d directories, want %d", want.name, len(got.children), len(want.children))
+	}
+	for idx := range want.children {
+		if got.children[idx].parent != got {
+			t.Fatalf("%q does not point back to %q", got.children[idx].name, got.name)
+		}
+		assertSameDirectory(t, got.children[idx], want.children[idx])
+	}
+}
+
+func FuzzParseDirectoryFromStrings(f *testing.F) {
+	f.Add(readExample(f, "day7.txt"))
+	f.Add("$ cd /\n$ ls\ndir a\n$ cd a\n$ cd ..\n$ cd ..")
+	f.Add("$ ls\n12 b.txt\ndir /")
+
+	f.Fuzz(func(t *testing.T, transcript string) {
+		root, err := parseDirectoryFromStrings(strings.Split(transcript, "\n"))
+		if err != nil {
+			return
+		}
+		reparsed, err := parseDirectoryFromStrings(formatDirectoryTree(root))
+		if err != nil {
+			t.Fatalf("parseDirectoryFromStrings failed on its own output: %v", err)
+		}
+		assertSameDirectory(t, reparsed, root)
+	})
+}
+
+// getDirectorySizesFromPaths keeps every directory and file as a full path, and
+// sums for every directory the files whose path starts with it.
+func getDirectorySizesFromPaths(input *PuzzleInput) ([]int, error) {
+	directories := map[string]bool{"/": true}
+	files := map[string]int{}
+	cwd := "/"
+	for _, row := range input.Lines() {
+		fields := strings.Fields(row)
+		if strings.Count(row, "/") > 0 && row != "$ cd /" {
+			return nil, fmt.Errorf("name with a / in %q", row)
+		}
+		switch {
+		case row == "$ ls":
+		case row == "$ cd /":
+			cwd = "/"
+		case row == "$ cd ..":
+			if cwd == "/" {
+				return nil, fmt.Errorf("cd .. in /")
+			}
+			cwd = cwd[:strings.LastIndex(cwd[:len(cwd)-1], "/")+1]
+		case len(fields) == 3 && fields[0] == "$" && fields[1] == "cd":
+			if !directories[cwd+fields[2]+"/"] {
+				return nil, fmt.Errorf("cd into %q, which is not listed", fields[2])
+			}
+			cwd += fields[2] + "/"
+		case len(fields) == 2 && fields[0] == "dir":
+			directories[cwd+fields[1]+"/"] = true
+		case len(fields) == 2:
+			size, err := strconv.Atoi(fields[0])
+			if err != nil || size < 0 {
+				return nil, fmt.Errorf("not a file: %q", row)
+			}
+			if _, ok := files[cwd+fields[1]]; ok {
+				return nil, fmt.Errorf("file %q listed twice", cwd+fields[1])
+			}
+			files[cwd+fields[1]] = size
+		default:
+			return nil, fmt.Errorf("not a command or listing: %q", row)
+		}
+	}
+
+	sizes := []int{}
+	for directory := range directories {
+		size := 0
+		for file, fileSize := range files {
+			if strings.HasPrefix(file, directory) {
+				size += fileSize
+			}
+		}
+		sizes = append(sizes, size)
+	}
+	sort.Ints(sizes)
+	return sizes, nil
+}
+
+func day7_part1_naive(input *PuzzleInput) (string, error) {
+	sizes, err := getDirectorySizesFromPaths(input)
+	if err != nil {
+		return "", err
+	}
+	sum := 0
+	for _, size := range sizes {
+		if size <= 100000 {
+			sum += size
+		}
+	}
+	return strconv.Itoa(sum), nil
+}
+
+func day7_part2_naive(input *PuzzleInput) (string, error) {
+	sizes, err := getDirectorySizesFromPaths(input)
+	if err != nil {
+		return "", err
+	}
+	// the root is the largest directory, and the last after sorting
+	needToFree := 30000000 - (70000000 - sizes[len(sizes)-1])
+	for _, size := range sizes {
+		if size >= needToFree {
+			return strconv.Itoa(size), nil
+		}
+	}
+	return "", fmt.Errorf("no directory frees %d", needToFree)
+}
+
+func TestDay7DirectoriesWithTheSameName(t *testing.T) {
+	input := "$ cd /\n$ ls\ndir a\ndir b\n$ cd a\n$ ls\ndir x\n$ cd x\n$ ls\n10 f\n$ cd /\n$ cd b\n$ ls\ndir a\n$ cd a\n$ ls\ndir x\n$ cd x\n$ ls\n20 g"
+	root, err := parseDay7(newPuzzleInput([]byte(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// /a/x, /a, /b/a/x, /b/a, /b and /
+	answer, err := day7_part1(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer.Int() != 10+10+20+20+20+30 {
+		t.Errorf("day7_part1() = %d, want 110", answer.Int())
+	}
+}
@@ -2,18 +2,54 @@ package main
 
 import (
 	"fmt"
-	"log"
 )
 
-func getTreeMatrixFromString(rows []string) *[][]int {
+func lintTreeRows(rows []string) []error {
+	if len(rows) == 0 || rows[0] == "" {
+		return []error{newParseError(1, 1, "", "", "a row of tree heights")}
+	}
+	var errs []error
+	for idx, treeRow := range rows {
+		if len(treeRow) != len(rows[0]) {
+			errs = append(errs, newParseError(idx+1, 1, treeRow, treeRow, fmt.Sprintf("a row of %d tree heights", len(rows[0]))))
+		}
+		for jdx, tree := range treeRow {
+			if tree < '0' || tree > '9' {
+				errs = append(errs, newParseError(idx+1, jdx+1, treeRow, string(tree), "a tree height between 0 and 9"))
+			}
+		}
+	}
+	return errs
+}
+
+func getTreeMatrixFromString(rows []string) (*[][]int, error) {
+	if errs := lintTreeRows(rows); len(errs) > 0 {
+		return nil, errs[0]
+	}
 	treeMatrix := make([][]int, len(rows))
 	for idx, treeRow := range rows {
 		treeMatrix[idx] = make([]int, len(treeRow))
 		for jdx, tree := range treeRow {
-			treeMatrix[idx][jdx] = convertStringToInt(string(tree))
+			treeMatrix[idx][jdx] = int(tree - '0')
+		}
+	}
+	return &treeMatrix, nil
+}
+
+func lintDay8(input *PuzzleInput) []error {
+	return lintTreeRows(input.Lines())
+}
+
+func formatTreeMatrix(treeMatrix *[][]int) []string {
+	rows := make([]string, len(*treeMatrix))
+	for idx, treeRow := range *treeMatrix {
+		row := make([]byte, len(treeRow))
+		for jdx, tree := range treeRow {
+			row[jdx] = byte('0' + tree)
 		}
+		rows[idx] = string(row)
 	}
-	return &treeMatrix
+	return rows
 }
 
 func printTreeMatrix(treeMatrix *[][]int) {
@@ -66,7 +102,7 @@ func getViewableTreesMatrix(treeMatrix *[][]int) *[][]int {
 	for jdx := 0; jdx < len((*treeMatrix)[idx]); jdx++ {
 		highestTree := -1
 		// from top -> bottom
-		for ; idx < len(*treeMatrix)-1; idx++ {
+		for ; idx < len(*treeMatrix); idx++ {
 			tree := (*treeMatrix)[idx][jdx]
 			if idx == 0 {
 				highestTree = tree
@@ -109,19 +145,16 @@ func countNrOfVisibleTrees(viewableTreesMatrix *[][]int) int {
 	return nrOfVisibleTrees
 }
 
-func day8_part1() {
-	rows, err := getRowsFromFile("input8.txt")
-
-	if err != nil {
-		log.Fatal(err)
-	}
+func parseDay8(input *PuzzleInput) (*[][]int, error) {
+	return getTreeMatrixFromString(input.Lines())
+}
 
-	treeMatrix := getTreeMatrixFromString(rows)
+func day8_part1(treeMatrix *[][]int) (Answer, error) {
 	// printTreeMatrix(treeMatrix)
 	viewableTreesMatrix := getViewableTreesMatrix(treeMatrix)
 	// printTreeMatrix(viewableTreesMatrix)
 	solution := countNrOfVisibleTrees(viewableTreesMatrix)
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return newIntAnswer(solution), nil
 }
 
 func getScenicScoreForePosition(treeMatrix *[][]int, x int, y int) int {
@@ -160,7 +193,7 @@ func getScenicScoreForePosition(treeMatrix *[][]int, x int, y int) int {
 	}
 
 	bottomScore := 0
-	for idx := x + 1; idx < len(*treeMatrix)-1; idx++ {
+	for idx := x + 1; idx < len(*treeMatrix); idx++ {
 		viewedTreeHeight := (*treeMatrix)[idx][y]
 		if viewedTreeHeight < currentTreeHeight {
 			bottomScore++
@@ -206,17 +239,10 @@ func findHighestScenicScore(scenicScoreMatrix *[][]int) int {
 	return highestScenicScore
 }
 
-func day8_part2() {
-	rows, err := getRowsFromFile("input8.txt")
-
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	treeMatrix := getTreeMatrixFromString(rows)
+func day8_part2(treeMatrix *[][]int) (Answer, error) {
 	// printTreeMatrix(treeMatrix)
 	viewableTreesMatrix := getScenicScoreMatrix(treeMatrix)
 	// printTreeMatrix(viewableTreesMatrix)
 	solution := findHighestScenicScore(viewableTreesMatrix)
-	fmt.Println(getFunctionName(), " solution: ", solution)
+	return newIntAnswer(solution), nil
 }
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGetScenicScoreForePosition(t *testing.T) {
+	treeMatrix, err := getTreeMatrixFromString([]string{"30373", "25512", "65332", "33549", "35390"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		x    int
+		y    int
+		want int
+	}{
+		{1, 2, 4},
+		{3, 2, 8},
+		{1, 1, 1},
+		{2, 2, 1},
+	}
+
+	for _, test := range tests {
+		if got := getScenicScoreForePosition(treeMatrix, test.x, test.y); got != test.want {
+			t.Errorf("getScenicScoreForePosition(%d, %d) = %d, want %d", test.x, test.y, got, test.want)
+		}
+	}
+}
+
+func FuzzGetTreeMatrixFromString(f *testing.F) {
+	f.Add(readExample(f, "day8.txt"))
+	f.Add("0")
+	f.Add("12\n3")
+
+	f.Fuzz(func(t *testing.T, trees string) {
+		treeMatrix, err := getTreeMatrixFromString(strings.Split(trees, "\n"))
+		if err != nil {
+			return
+		}
+		reparsed, err := getTreeMatrixFromString(formatTreeMatrix(treeMatrix))
+		if err != nil {
+			t.Fatalf("getTreeMatrixFromString failed on its own output: %v", err)
+		}
+		if !reflect.DeepEqual(reparsed, treeMatrix) {
+			t.Errorf("round trip of %q gave %v, want %v", trees, *reparsed, *treeMatrix)
+		}
+	})
+}
+
+func BenchmarkGetViewableTreesMatrix(b *testing.B) {
+	treeMatrix, err := parseDay8(newPuzzleInput(readEmbeddedInput(b, 8)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		getViewableTreesMatrix(treeMatrix)
+	}
+}
+
+func BenchmarkGetScenicScoreMatrix(b *testing.B) {
+	treeMatrix, err := parseDay8(newPuzzleInput(readEmbeddedInput(b, 8)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		getScenicScoreMatrix(treeMatrix)
+	}
+}
+
+// getForest reads the digits, the forest has to be a rectangle.
+func getForest(input *PuzzleInput) ([][]int, error) {
+	rows := input.Lines()
+	forest := make([][]int, len(rows))
+	for idx, row := range rows {
+		if row == "" || len(row) != len(rows[0]) {
+			return nil, fmt.Errorf("row %d does not fit the forest", idx+1)
+		}
+		for _, tree := range row {
+			if tree < '0' || tree > '9' {
+				return nil, fmt.Errorf("not a tree: %q", tree)
+			}
+			forest[idx] = append(forest[idx], int(tree-'0'))
+		}
+	}
+	if len(forest) == 0 {
+		return nil, fmt.Errorf("no trees")
+	}
+	return forest, nil
+}
+
+// lookFromTree walks from a tree to the edge in one direction, and returns
+// how many trees it sees and whether it reached the edge.
+func lookFromTree(forest [][]int, x, y, dx, dy int) (int, bool) {
+	seen := 0
+	for i, j := x+dx, y+dy; i >= 0 && i < len(forest) && j >= 0 && j < len(forest[i]); i, j = i+dx, j+dy {
+		seen++
+		if forest[i][j] >= forest[x][y] {
+			return seen, false
+		}
+	}
+	return seen, true
+}
+
+var lookDirections = [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+
+func day8_part1_naive(input *PuzzleInput) (string, error) {
+	forest, err := getForest(input)
+	if err != nil {
+		return "", err
+	}
+	visible := 0
+	for x := range forest {
+		for y := range forest[x] {
+			for _, direction := range lookDirections {
+				if _, edge := lookFromTree(forest, x, y, direction[0], direction[1]); edge {
+					visible++
+					break
+				}
+			}
+		}
+	}
+	return strconv.Itoa(visible), nil
+}
+
+func day8_part2_naive(input *PuzzleInput) (string, error) {
+	forest, err := getForest(input)
+	if err != nil {
+		return "", err
+	}
+	best := 0
+	for x := range forest {
+		for y := range forest[x] {
+			score := 1
+			for _, direction := range lookDirections {
+				seen, _ := lookFromTree(forest, x, y, direction[0], direction[1])
+				score *= seen
+			}
+			if score > best {
+				best = score
+			}
+		}
+	}
+	return strconv.Itoa(best), nil
+}
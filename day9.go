@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"log"
 	"strconv"
 	"strings"
@@ -48,175 +47,161 @@ func abs(x int) int {
 	return x
 }
 
-func getInstructionsFromStrings(rows []string) *[]SnakeInstruction {
-	instructions := make([]SnakeInstruction, 0)
+func getSnakeInstructionFromString(line int, row string) (SnakeInstruction, error) {
+	parsedRow := strings.Split(row, " ")
+	if len(parsedRow) != 2 {
+		return SnakeInstruction{}, newParseError(line, 1, row, row, "a direction and a number of steps like \"R 4\"")
+	}
 
-	for _, row := range rows {
-		parsedRow := strings.Split(row, " ")
-
-		var direction Direction
-		switch parsedRow[0] {
-		case "U":
-			direction = Up
-		case "D":
-			direction = Down
-		case "L":
-			direction = Left
-		case "R":
-			direction = Right
-		default:
-			log.Fatalf("Not a valid direction while parsing: %s", parsedRow[0])
-		}
+	var direction Direction
+	switch parsedRow[0] {
+	case "U":
+		direction = Up
+	case "D":
+		direction = Down
+	case "L":
+		direction = Left
+	case "R":
+		direction = Right
+	default:
+		return SnakeInstruction{}, newFieldParseError(line, row, parsedRow, 0, "U, D, L or R")
+	}
+
+	steps, err := strconv.Atoi(parsedRow[1])
+	if err != nil || steps < 0 {
+		return SnakeInstruction{}, newFieldParseError(line, row, parsedRow, 1, "a number of steps")
+	}
+
+	return SnakeInstruction{direction: direction, steps: steps}, nil
+}
 
-		steps, err := strconv.Atoi(parsedRow[1])
+func getInstructionsFromStrings(rows []string) (*[]SnakeInstruction, error) {
+	instructions := make([]SnakeInstruction, 0)
+
+	for idx, row := range rows {
+		instruction, err := getSnakeInstructionFromString(idx+1, row)
 		if err != nil {
-			log.Fatalf("Not a valid number while parsing: %s", parsedRow[1])
+			return nil, err
 		}
-
-		instruction := SnakeInstruction{direction: direction, steps: steps}
 		instructions = append(instructions, instruction)
 	}
 
-	return &instructions
+	return &instructions, nil
+}
+
+func (s SnakeInstruction) String() string {
+	return s.direction.String()[0:1] + " " + strconv.Itoa(s.steps)
 }
 
 func isPosAdjecent(pos1 Position, pos2 Position) bool {
-    x_diff := abs(pos1.x - pos2.x)
-    y_diff := abs(pos1.y - pos2.y)
+	x_diff := abs(pos1.x - pos2.x)
+	y_diff := abs(pos1.y - pos2.y)
 
-    return 0 <= x_diff && x_diff <= 1 && 0 <= y_diff && y_diff <= 1
+	return 0 <= x_diff && x_diff <= 1 && 0 <= y_diff && y_diff <= 1
 }
 
 func getNewPos(headPos Position, tailPos Position) Position {
-    if headPos.x == tailPos.x && (headPos.y - tailPos.y) >= 1 {
-        // north
-        return Position{tailPos.x, tailPos.y + 1}
-    } else if headPos.x == tailPos.x && (headPos.y - tailPos.y) <= 1 {
-        // south
-        return Position{tailPos.x, tailPos.y - 1}
-    } else if headPos.y == tailPos.y && (headPos.x - tailPos.x) >= 1 {
-        // east
-        return Position{tailPos.x + 1, tailPos.y}
-    } else if headPos.y == tailPos.y && (headPos.x - tailPos.x) <= 1 {
-        // west
-        return Position{tailPos.x - 1, tailPos.y}
-    } else if (headPos.x - tailPos.x) >= 1 && (headPos.y - tailPos.y) >= 1 {
-        // north-east
-        return Position{tailPos.x + 1, tailPos.y + 1}
-    } else if (headPos.x - tailPos.x) >= 1 && (headPos.y - tailPos.y) <= 1 {
-        // south-east
-        return Position{tailPos.x + 1, tailPos.y - 1}
-    } else if (headPos.x - tailPos.x) <= 1 && (headPos.y - tailPos.y) >= 1 {
-        // north-west
-        return Position{tailPos.x - 1, tailPos.y + 1}
-    } else if (headPos.x - tailPos.x) <= 1 && (headPos.y - tailPos.y) <= 1 {
-        // south-west
-        return Position{tailPos.x - 1, tailPos.y - 1}
-    } else {
-        log.Fatalf("Not adjecent positions: %v, %v", headPos, tailPos)
-    }
-
-    return Position{0, 0}
-}
-
-func day9_part1() {
-	rows, err := getRowsFromFile("input9.txt")
+	if headPos.x == tailPos.x && (headPos.y-tailPos.y) >= 1 {
+		// north
+		return Position{tailPos.x, tailPos.y + 1}
+	} else if headPos.x == tailPos.x && (headPos.y-tailPos.y) <= 1 {
+		// south
+		return Position{tailPos.x, tailPos.y - 1}
+	} else if headPos.y == tailPos.y && (headPos.x-tailPos.x) >= 1 {
+		// east
+		return Position{tailPos.x + 1, tailPos.y}
+	} else if headPos.y == tailPos.y && (headPos.x-tailPos.x) <= 1 {
+		// west
+		return Position{tailPos.x - 1, tailPos.y}
+	} else if (headPos.x-tailPos.x) >= 1 && (headPos.y-tailPos.y) >= 1 {
+		// north-east
+		return Position{tailPos.x + 1, tailPos.y + 1}
+	} else if (headPos.x-tailPos.x) >= 1 && (headPos.y-tailPos.y) <= 1 {
+		// south-east
+		return Position{tailPos.x + 1, tailPos.y - 1}
+	} else if (headPos.x-tailPos.x) <= 1 && (headPos.y-tailPos.y) >= 1 {
+		// north-west
+		return Position{tailPos.x - 1, tailPos.y + 1}
+	} else if (headPos.x-tailPos.x) <= 1 && (headPos.y-tailPos.y) <= 1 {
+		// south-west
+		return Position{tailPos.x - 1, tailPos.y - 1}
+	} else {
+		log.Panicf("Not adjecent positions: %v, %v", headPos, tailPos)
+	}
 
-	if err != nil {
-		log.Fatal(err)
+	return Position{0, 0}
+}
+
+// Snake is a rope of sections that keeps track of where its tail has been.
+type Snake struct {
+	sections      []Position
+	visitedByTail map[Position]bool
+}
+
+func newSnake(numberOfSections int) *Snake {
+	snake := &Snake{sections: make([]Position, numberOfSections), visitedByTail: make(map[Position]bool)}
+	snake.visitedByTail[snake.sections[numberOfSections-1]] = true
+	return snake
+}
+
+func (s *Snake) move(instruction SnakeInstruction) {
+	head := &s.sections[0]
+	for step := 0; step < instruction.steps; step++ {
+		switch instruction.direction {
+		case Up:
+			head.y += 1
+		case Down:
+			head.y -= 1
+		case Left:
+			head.x -= 1
+		case Right:
+			head.x += 1
+		default:
+			log.Panicf("Not a valid direction: %s", instruction.direction)
+		}
+
+		currentHead := s.sections[0]
+		for i := 1; i < len(s.sections); i++ {
+			if !isPosAdjecent(currentHead, s.sections[i]) {
+				s.sections[i] = getNewPos(currentHead, s.sections[i])
+			}
+			currentHead = s.sections[i]
+		}
+		s.visitedByTail[s.sections[len(s.sections)-1]] = true
+	}
+}
+
+func getNumberOfVisitedTailPositions(lines *LineScanner, numberOfSections int) (int, error) {
+	snake := newSnake(numberOfSections)
+	for lines.Scan() {
+		instruction, err := getSnakeInstructionFromString(lines.Line(), lines.Text())
+		if err != nil {
+			return 0, err
+		}
+		snake.move(instruction)
 	}
+	return len(snake.visitedByTail), lines.Err()
+}
 
-    instructions := getInstructionsFromStrings(rows[:len(rows)-1])
-
-    numberOfSections := 2 
-    visitedPositions := make([]map[Position]bool, numberOfSections) 
-    snake := make([]Position, numberOfSections)
-
-    for i := 0; i < numberOfSections; i++ {
-        visitedPositions[i] = make(map[Position]bool) 
-        snake[i] = Position{0, 0}
-        visitedPositions[i][snake[i]] = true
-    }
-
-    for _, instruction := range *instructions {
-        head := &snake[0]
-        for step := 0; step < instruction.steps; step++ {
-            switch instruction.direction {
-            case Up:
-                head.y += 1
-            case Down:
-                head.y -= 1
-            case Left:
-                head.x -= 1
-            case Right:
-                head.x += 1
-            default:
-                log.Fatalf("Not a valid direction: %s", instruction.direction)
-            }
-
-            visitedPositions[0][*head] = true
-
-            currentHead := snake[0]
-            for i := 1; i < numberOfSections; i++ {
-                if !isPosAdjecent(currentHead, snake[i]) {
-                    snake[i] = getNewPos(currentHead, snake[i])
-                    visitedPositions[i][snake[i]] = true
-                }
-                currentHead = snake[i]
-            }
-        }
-    }
-    solution := len(visitedPositions[numberOfSections - 1])
-    fmt.Println(getFunctionName(), solution)
-}
-
-func day9_part2() {
-	rows, err := getRowsFromFile("input9.txt")
+func day9_part1(lines *LineScanner) (Answer, error) {
+	solution, err := getNumberOfVisitedTailPositions(lines, 2)
+	if err != nil {
+		return Answer{}, err
+	}
+	return newIntAnswer(solution), nil
+}
 
+func day9_part2(lines *LineScanner) (Answer, error) {
+	solution, err := getNumberOfVisitedTailPositions(lines, 10)
 	if err != nil {
-		log.Fatal(err)
+		return Answer{}, err
 	}
+	return newIntAnswer(solution), nil
+}
 
-    instructions := getInstructionsFromStrings(rows[:len(rows)-1])
-
-    numberOfSections := 10 
-    visitedPositions := make([]map[Position]bool, numberOfSections) 
-    snake := make([]Position, numberOfSections)
-
-    for i := 0; i < numberOfSections; i++ {
-        visitedPositions[i] = make(map[Position]bool) 
-        snake[i] = Position{0, 0}
-        visitedPositions[i][snake[i]] = true
-    }
-
-    for _, instruction := range *instructions {
-        head := &snake[0]
-        for step := 0; step < instruction.steps; step++ {
-            switch instruction.direction {
-            case Up:
-                head.y += 1
-            case Down:
-                head.y -= 1
-            case Left:
-                head.x -= 1
-            case Right:
-                head.x += 1
-            default:
-                log.Fatalf("Not a valid direction: %s", instruction.direction)
-            }
-
-            visitedPositions[0][*head] = true
-
-            currentHead := snake[0]
-            for i := 1; i < numberOfSections; i++ {
-                if !isPosAdjecent(currentHead, snake[i]) {
-                    snake[i] = getNewPos(currentHead, snake[i])
-                    visitedPositions[i][snake[i]] = true
-                }
-                currentHead = snake[i]
-            }
-        }
-    }
-    solution := len(visitedPositions[numberOfSections - 1])
-    fmt.Println(getFunctionName(), solution)
+func lintDay9(input *PuzzleInput) []error {
+	return lintRows(input.Lines(), func(line int, row string) error {
+		_, err := getSnakeInstructionFromString(line, row)
+		return err
+	})
 }
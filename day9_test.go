@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGetNewPos(t *testing.T) {
+	tests := []struct {
+		head Position
+		tail Position
+		want Position
+	}{
+		{Position{2, 0}, Position{0, 0}, Position{1, 0}},
+		{Position{-2, 0}, Position{0, 0}, Position{-1, 0}},
+		{Position{0, 2}, Position{0, 0}, Position{0, 1}},
+		{Position{0, -2}, Position{0, 0}, Position{0, -1}},
+		{Position{1, 2}, Position{0, 0}, Position{1, 1}},
+		{Position{2, 1}, Position{0, 0}, Position{1, 1}},
+		{Position{2, -1}, Position{0, 0}, Position{1, -1}},
+		{Position{-1, 2}, Position{0, 0}, Position{-1, 1}},
+		{Position{-2, -2}, Position{0, 0}, Position{-1, -1}},
+	}
+
+	for _, test := range tests {
+		if got := getNewPos(test.head, test.tail); got != test.want {
+			t.Errorf("getNewPos(%v, %v) = %v, want %v", test.head, test.tail, got, test.want)
+		}
+	}
+}
+
+func FuzzGetInstructionsFromStrings(f *testing.F) {
+	f.Add(readExample(f, "day9.txt"))
+	f.Add("U 0\nL 12")
+	f.Add("R -1")
+
+	f.Fuzz(func(t *testing.T, motions string) {
+		instructions, err := getInstructionsFromStrings(strings.Split(motions, "\n"))
+		if err != nil {
+			return
+		}
+		rows := make([]string, len(*instructions))
+		for idx, instruction := range *instructions {
+			rows[idx] = instruction.String()
+		}
+		reparsed, err := getInstructionsFromStrings(rows)
+		if err != nil {
+			t.Fatalf("getInstructionsFromStrings failed on its own output: %v", err)
+		}
+		if !reflect.DeepEqual(reparsed, instructions) {
+			t.Errorf("round trip of %q gave %v, want %v", motions, *reparsed, *instructions)
+		}
+	})
+}
+
+func BenchmarkSnakeMove(b *testing.B) {
+	instructions, err := getInstructionsFromStrings(newPuzzleInput(readEmbeddedInput(b, 9)).Lines())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		snake := newSnake(10)
+		for _, instruction := range *instructions {
+			snake.move(instruction)
+		}
+	}
+}
+
+func sign(x int) int {
+	if x < 0 {
+		return -1
+	} else if x > 0 {
+		return 1
+	}
+	return 0
+}
+
+// countTailPositions moves the head one step at the time and lets every
+// knot step towards the one before it while they are not touching.
+func countTailPositions(input *PuzzleInput, knots int) (string, error) {
+	xs, ys := make([]int, knots), make([]int, knots)
+	visited := map[string]bool{"0,0": true}
+	for _, row := range input.Lines() {
+		var direction string
+		var steps int
+		if _, err := fmt.Sscanf(row, "%s %d", &direction, &steps); err != nil || steps < 0 {
+			return "", fmt.Errorf("not a motion: %q", row)
+		}
+		dx, dy := 0, 0
+		switch direction {
+		case "U":
+			dy = 1
+		case "D":
+			dy = -1
+		case "L":
+			dx = -1
+		case "R":
+			dx = 1
+		default:
+			return "", fmt.Errorf("not a direction: %q", direction)
+		}
+
+		for step := 0; step < steps; step++ {
+			xs[0], ys[0] = xs[0]+dx, ys[0]+dy
+			for knot := 1; knot < knots; knot++ {
+				if abs(xs[knot-1]-xs[knot]) > 1 || abs(ys[knot-1]-ys[knot]) > 1 {
+					xs[knot] += sign(xs[knot-1] - xs[knot])
+					ys[knot] += sign(ys[knot-1] - ys[knot])
+				}
+			}
+			visited[fmt.Sprintf("%d,%d", xs[knots-1], ys[knots-1])] = true
+		}
+	}
+	return strconv.Itoa(len(visited)), nil
+}
+
+func day9_part1_naive(input *PuzzleInput) (string, error) {
+	return countTailPositions(input, 2)
+}
+
+func day9_part2_naive(input *PuzzleInput) (string, error) {
+	return countTailPositions(input, 10)
+}
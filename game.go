@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// HandDefinition is a hand of a game as written in a JSON game file. The
+// opponent and response symbols are the letters of the strategy guide.
+type HandDefinition struct {
+	Name     string   `json:"name"`
+	Opponent string   `json:"opponent"`
+	Response string   `json:"response"`
+	Points   int      `json:"points"`
+	Beats    []string `json:"beats"`
+}
+
+type OutcomePoints struct {
+	Lost int `json:"lost"`
+	Draw int `json:"draw"`
+	Won  int `json:"won"`
+}
+
+// GameDefinition describes a cyclic hand game: its hands, which hand beats
+// which, and the points of every hand and outcome.
+type GameDefinition struct {
+	Name   string           `json:"name"`
+	Hands  []HandDefinition `json:"hands"`
+	Points OutcomePoints    `json:"points"`
+}
+
+var gamePresets = map[string]GameDefinition{
+	"rps": {
+		Name: "Rock Paper Scissors",
+		Hands: []HandDefinition{
+			{Name: "Rock", Opponent: "A", Response: "X", Points: 1, Beats: []string{"Scissors"}},
+			{Name: "Paper", Opponent: "B", Response: "Y", Points: 2, Beats: []string{"Rock"}},
+			{Name: "Scissors", Opponent: "C", Response: "Z", Points: 3, Beats: []string{"Paper"}},
+		},
+		Points: OutcomePoints{Lost: 0, Draw: 3, Won: 6},
+	},
+	"rpsls": {
+		Name: "Rock Paper Scissors Lizard Spock",
+		Hands: []HandDefinition{
+			{Name: "Rock", Opponent: "A", Response: "X", Points: 1, Beats: []string{"Scissors", "Lizard"}},
+			{Name: "Paper", Opponent: "B", Response: "Y", Points: 2, Beats: []string{"Rock", "Spock"}},
+			{Name: "Scissors", Opponent: "C", Response: "Z", Points: 3, Beats: []string{"Paper", "Lizard"}},
+			{Name: "Lizard", Opponent: "D", Response: "V", Points: 4, Beats: []string{"Spock", "Paper"}},
+			{Name: "Spock", Opponent: "E", Response: "W", Points: 5, Beats: []string{"Scissors", "Rock"}},
+		},
+		Points: OutcomePoints{Lost: 0, Draw: 3, Won: 6},
+	},
+}
+
+// Hand is the position of a hand in its game.
+type Hand int
+
+// Game is a GameDefinition checked and turned into lookup tables.
+type Game struct {
+	name            string
+	hands           []string
+	handPoints      []int
+	beats           [][]bool
+	outcomePoints   [3]int
+	opponentSymbols string
+	responseSymbols string
+}
+
+// day2Game is the game played by the day 2 solvers, set through -game.
+var day2Game = mustNewGame(gamePresets["rps"])
+
+func addGameFlag(flagSet *flag.FlagSet) {
+	usage := fmt.Sprintf("game played on day 2: %s or a JSON game file", strings.Join(getGamePresetNames(), ", "))
+	flagSet.Func("game", usage, func(value string) error {
+		game, err := getGame(value)
+		if err != nil {
+			return err
+		}
+		day2Game = game
+		return nil
+	})
+}
+
+// getGame returns a preset by name, anything else is read as a JSON file.
+func getGame(value string) (*Game, error) {
+	if definition, ok := gamePresets[value]; ok {
+		return newGame(definition)
+	}
+	return loadGame(value)
+}
+
+func loadGame(path string) (*Game, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var definition GameDefinition
+	if err := json.Unmarshal(data, &definition); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	game, err := newGame(definition)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return game, nil
+}
+
+func getGameSymbol(hand HandDefinition, kind string, symbol string, symbols string) (byte, error) {
+	if len(symbol) != 1 || symbol == " " {
+		return 0, fmt.Errorf("hand %q: %s symbol %q is not a single letter", hand.Name, kind, symbol)
+	}
+	if strings.Contains(symbols, symbol) {
+		return 0, fmt.Errorf("hand %q: %s symbol %q is used twice", hand.Name, kind, symbol)
+	}
+	return symbol[0], nil
+}
+
+// newGame checks that the game has at least three hands, that the hands and
+// symbols are unique, and that the game is cyclic: no two hands beat each
+// other and every hand beats and loses to at least one other hand, so every
+// outcome can be played against every hand.
+func newGame(definition GameDefinition) (*Game, error) {
+	if len(definition.Hands) < 3 {
+		return nil, fmt.Errorf("a game needs at least 3 hands, got %d", len(definition.Hands))
+	}
+	game := &Game{
+		name:          definition.Name,
+		outcomePoints: [3]int{definition.Points.Lost, definition.Points.Draw, definition.Points.Won},
+	}
+	handIds := map[string]Hand{}
+	for idx, hand := range definition.Hands {
+		if _, ok := handIds[hand.Name]; ok || hand.Name == "" {
+			return nil, fmt.Errorf("hand %d: name %q is empty or used twice", idx+1, hand.Name)
+		}
+		handIds[hand.Name] = Hand(idx)
+
+		opponent, err := getGameSymbol(hand, "opponent", hand.Opponent, game.opponentSymbols)
+		if err != nil {
+			return nil, err
+		}
+		response, err := getGameSymbol(hand, "response", hand.Response, game.responseSymbols)
+		if err != nil {
+			return nil, err
+		}
+		game.hands = append(game.hands, hand.Name)
+		game.handPoints = append(game.handPoints, hand.Points)
+		game.opponentSymbols += string(opponent)
+		game.responseSymbols += string(response)
+	}
+
+	game.beats = make([][]bool, len(game.hands))
+	for idx := range game.beats {
+		game.beats[idx] = make([]bool, len(game.hands))
+	}
+	for idx, hand := range definition.Hands {
+		for _, name := range hand.Beats {
+			beaten, ok := handIds[name]
+			if !ok {
+				return nil, fmt.Errorf("hand %q beats unknown hand %q", hand.Name, name)
+			}
+			if beaten == Hand(idx) {
+				return nil, fmt.Errorf("hand %q beats itself", hand.Name)
+			}
+			game.beats[idx][beaten] = true
+		}
+	}
+
+	for winner := range game.hands {
+		wins, losses := 0, 0
+		for loser := range game.hands {
+			if game.beats[winner][loser] && game.beats[loser][winner] {
+				return nil, fmt.Errorf("hands %q and %q beat each other", game.hands[winner], game.hands[loser])
+			}
+			if game.beats[winner][loser] {
+				wins++
+			}
+			if game.beats[loser][winner] {
+				losses++
+			}
+		}
+		if wins == 0 || losses == 0 {
+			return nil, fmt.Errorf("hand %q has to beat and lose to at least one hand", game.hands[winner])
+		}
+	}
+	return game, nil
+}
+
+func mustNewGame(definition GameDefinition) *Game {
+	game, err := newGame(definition)
+	if err != nil {
+		panic(err)
+	}
+	return game
+}
+
+func getGamePresetNames() []string {
+	names := make([]string, 0, len(gamePresets))
+	for name := range gamePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (g *Game) getHandName(hand Hand) string {
+	return g.hands[hand]
+}
+
+func (g *Game) getOpponentHand(symbol byte) Hand {
+	return Hand(strings.IndexByte(g.opponentSymbols, symbol))
+}
+
+func (g *Game) getResponseHand(symbol byte) Hand {
+	return Hand(strings.IndexByte(g.responseSymbols, symbol))
+}
+
+func (g *Game) getResult(opponent Hand, you Hand) ExpectedResult {
+	switch {
+	case g.beats[you][opponent]:
+		return Won
+	case g.beats[opponent][you]:
+		return Lost
+	default:
+		return Draw
+	}
+}
+
+func (g *Game) getWinnerPoints(opponent Hand, you Hand) int {
+	return g.outcomePoints[g.getResult(opponent, you)]
+}
+
+func (g *Game) getHandPoints(hand Hand) int {
+	return g.handPoints[hand]
+}
+
+// getYourHand returns the first hand, in the order of the game, that gives
+// the expected result against the opponent.
+func (g *Game) getYourHand(expectedResult ExpectedResult, opponent Hand) Hand {
+	if expectedResult == Draw {
+		return opponent
+	}
+	for hand := range g.hands {
+		if g.getResult(opponent, Hand(hand)) == expectedResult {
+			return Hand(hand)
+		}
+	}
+	panic(fmt.Sprintf("no hand gives %v against %v in %s", expectedResult, g.getHandName(opponent), g.name))
+}
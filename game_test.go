@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewGameRejectsBrokenGames(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(definition *GameDefinition)
+		want   string
+	}{
+		{"no hands", func(d *GameDefinition) { d.Hands = nil }, "at least 3 hands"},
+		{"two hands", func(d *GameDefinition) { d.Hands = d.Hands[:2] }, "at least 3 hands"},
+		{"duplicate hand", func(d *GameDefinition) { d.Hands[1].Name = "Rock" }, "used twice"},
+		{"long symbol", func(d *GameDefinition) { d.Hands[0].Opponent = "AA" }, "not a single letter"},
+		{"duplicate symbol", func(d *GameDefinition) { d.Hands[2].Response = "X" }, "used twice"},
+		{"unknown hand", func(d *GameDefinition) { d.Hands[0].Beats = []string{"Well"} }, "unknown hand"},
+		{"beats itself", func(d *GameDefinition) { d.Hands[0].Beats = []string{"Rock"} }, "beats itself"},
+		{"beat each other", func(d *GameDefinition) { d.Hands[0].Beats = []string{"Scissors", "Paper"} }, "beat each other"},
+		{"never loses", func(d *GameDefinition) { d.Hands[1].Beats = nil }, "has to beat and lose"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			definition := gamePresets["rps"]
+			definition.Hands = append([]HandDefinition{}, definition.Hands...)
+			test.change(&definition)
+			if _, err := newGame(definition); err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("newGame() error = %v, want %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestRockPaperScissorsLizardSpock(t *testing.T) {
+	game := mustNewGame(gamePresets["rpsls"])
+	for winner := range game.hands {
+		wins := 0
+		for loser := range game.hands {
+			if game.getResult(Hand(loser), Hand(winner)) == Won {
+				wins++
+			}
+		}
+		if wins != 2 {
+			t.Errorf("%s beats %d hands, want 2", game.getHandName(Hand(winner)), wins)
+		}
+	}
+
+	spock := game.getOpponentHand('E')
+	if got := game.getHandName(game.getYourHand(Won, spock)); got != "Paper" {
+		t.Errorf("getYourHand(Won, Spock) = %s, want Paper", got)
+	}
+	if got := game.getHandName(game.getYourHand(Lost, spock)); got != "Rock" {
+		t.Errorf("getYourHand(Lost, Spock) = %s, want Rock", got)
+	}
+}
+
+func TestDay2WithLoadedGame(t *testing.T) {
+	data, err := json.Marshal(gamePresets["rpsls"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "rpsls.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	game, err := getGame(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(previous *Game) { day2Game = previous }(day2Game)
+	day2Game = game
+	// Paper beats Spock, Lizard beats Paper and Scissors ties, part 2 picks
+	// the first hand of the game that gives the result
+	for part, want := range map[int]int{1: 2 + 6 + 4 + 6 + 3 + 3, 2: 2 + 6 + 1 + 0 + 3 + 3} {
+		input := "E Y\nB V\nC Z"
+		solve := day2_part1
+		if part == 2 {
+			input = "E Z\nB X\nC Y"
+			solve = day2_part2
+		}
+		answer, err := solve(newLineScanner(strings.NewReader(input), maxLineLength))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if answer.Int() != want {
+			t.Errorf("part %d = %d, want %d", part, answer.Int(), want)
+		}
+	}
+}
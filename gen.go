@@ -0,0 +1,392 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"math/bits"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Generator writes a valid puzzle input for a day, one row at the time, so
+// the inputs can be far larger than the memory. Size says how large the input
+// is, the meaning differs per day and is listed in generatorSizes. Errors of
+// the writer are left for its Flush.
+type Generator func(rng *rand.Rand, size int, writer *bufio.Writer) error
+
+var generators = map[int]Generator{
+	1:  generateDay1,
+	2:  generateDay2,
+	3:  generateDay3,
+	4:  generateDay4,
+	5:  generateDay5,
+	6:  generateDay6,
+	7:  generateDay7,
+	8:  generateDay8,
+	9:  generateDay9,
+	10: generateDay10,
+	11: generateDay11,
+	12: generateDay12,
+}
+
+var generatorSizes = map[int]string{
+	1:  "elves",
+	2:  "rounds",
+	3:  "groups of three rucksacks",
+	4:  "pairs of elves",
+	5:  "moves",
+	6:  "random characters before a guaranteed marker",
+	7:  "directories",
+	8:  "rows and columns of the forest",
+	9:  "motions",
+	10: "instructions",
+	11: "items spread over eight monkeys",
+	12: "rows and columns of the height map, at least 14",
+}
+
+const lowercaseLetters = "abcdefghijklmnopqrstuvwxyz"
+const itemLetters = lowercaseLetters + "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+var directoryNames = []string{"a", "b", "c", "d", "e"}
+
+func writeRow(writer *bufio.Writer, row string) {
+	writer.WriteString(row)
+	writer.WriteByte('\n')
+}
+
+func writeRows(writer *bufio.Writer, rows []string) {
+	for _, row := range rows {
+		writeRow(writer, row)
+	}
+}
+
+func generateDay1(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	for elf := 0; elf < size; elf++ {
+		if elf > 0 {
+			writeRow(writer, "")
+		}
+		for item := rng.Intn(10); item >= 0; item-- {
+			writeRow(writer, strconv.Itoa(1000+rng.Intn(60000)))
+		}
+	}
+	return nil
+}
+
+func generateDay2(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	for round := 0; round < size; round++ {
+		writeRow(writer, string([]byte{byte('A' + rng.Intn(3)), ' ', byte('X' + rng.Intn(3))}))
+	}
+	return nil
+}
+
+func pickItems(rng *rand.Rand, items string, n int) []byte {
+	picked := make([]byte, n)
+	for idx := range picked {
+		picked[idx] = items[rng.Intn(len(items))]
+	}
+	return picked
+}
+
+// generateDay3 gives every group its own badge, and every elf its own share
+// of the other items, split between the two compartments. This way the
+// compartments only share one item, and the group only shares the badge.
+func generateDay3(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	for group := 0; group < size; group++ {
+		letters := []byte(itemLetters)
+		rng.Shuffle(len(letters), func(i, j int) { letters[i], letters[j] = letters[j], letters[i] })
+		badge := letters[0]
+		for elf := 0; elf < 3; elf++ {
+			own := letters[1+elf*17 : 1+(elf+1)*17]
+			shared := own[0]
+			half := 2 + rng.Intn(20)
+
+			first := append([]byte{shared, badge}, pickItems(rng, string(own[1:9]), half-2)...)
+			second := append([]byte{shared}, pickItems(rng, string(own[9:]), half-1)...)
+			rng.Shuffle(len(first), func(i, j int) { first[i], first[j] = first[j], first[i] })
+			rng.Shuffle(len(second), func(i, j int) { second[i], second[j] = second[j], second[i] })
+			writeRow(writer, string(first)+string(second))
+		}
+	}
+	return nil
+}
+
+func generateRange(rng *rand.Rand) Ranges {
+	min := uint(1 + rng.Intn(99))
+	max := min + uint(rng.Intn(100-int(min)))
+	return Ranges{min, max}
+}
+
+func generateDay4(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	for pair := 0; pair < size; pair++ {
+		first, second := generateRange(rng), generateRange(rng)
+		writeRow(writer, first.String()+","+second.String())
+	}
+	return nil
+}
+
+// generateDay5 plays the moves while writing them, so every move only takes
+// crates that are there.
+func generateDay5(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	stacksArray := make([][]byte, 9)
+	for idx := range stacksArray {
+		stacksArray[idx] = pickItems(rng, strings.ToUpper(lowercaseLetters), 1+rng.Intn(8))
+	}
+	writeRows(writer, formatStacksArray(stacksArray))
+	writeRow(writer, "")
+
+	stacks := make([][]byte, len(stacksArray))
+	for idx, stack := range stacksArray {
+		stacks[idx] = append([]byte{}, stack...)
+	}
+	for move := 0; move < size; move++ {
+		from := rng.Intn(len(stacks))
+		for len(stacks[from]) == 0 {
+			from = rng.Intn(len(stacks))
+		}
+		to := rng.Intn(len(stacks) - 1)
+		if to >= from {
+			to++
+		}
+		nrToMove := 1 + rng.Intn(len(stacks[from]))
+
+		stacks[to] = append(stacks[to], stacks[from][len(stacks[from])-nrToMove:]...)
+		stacks[from] = stacks[from][:len(stacks[from])-nrToMove]
+		fmt.Fprintf(writer, "move %d from %d to %d\n", nrToMove, from+1, to+1)
+	}
+	return nil
+}
+
+// generateDay6 ends the signal with fourteen different characters, so there
+// always is a start of message marker.
+func generateDay6(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	marker := []byte(lowercaseLetters)
+	rng.Shuffle(len(marker), func(i, j int) { marker[i], marker[j] = marker[j], marker[i] })
+	for idx := 0; idx < size; idx++ {
+		writer.WriteByte(lowercaseLetters[rng.Intn(len(lowercaseLetters))])
+	}
+	writeRow(writer, string(marker[:14]))
+	return nil
+}
+
+// generateDay7 adds every directory below one of the earlier ones, mostly the
+// latest, which makes the tree deep. The names come from a small pool, so the
+// same name turns up in different places of the tree, only siblings get a
+// number added to keep them apart.
+func generateDay7(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	root := &Directory{name: "/"}
+	directories := []*Directory{root}
+	for idx := 0; idx < size; idx++ {
+		parent := directories[len(directories)-1]
+		if rng.Intn(3) == 0 {
+			parent = directories[rng.Intn(len(directories))]
+		}
+		name := directoryNames[rng.Intn(len(directoryNames))]
+		if doesDirectoryExist(parent, name) {
+			name += strconv.Itoa(idx)
+		}
+		directory := &Directory{name: name, parent: parent}
+		parent.children = append(parent.children, directory)
+		directories = append(directories, directory)
+	}
+	for _, directory := range directories {
+		for idx := rng.Intn(4); idx > 0; idx-- {
+			name := string(pickItems(rng, lowercaseLetters, 1+rng.Intn(8)))
+			if rng.Intn(2) == 0 {
+				name += "." + string(pickItems(rng, lowercaseLetters, 3))
+			}
+			directory.files = append(directory.files, File{name: name, size: 1 + rng.Intn(300000)})
+		}
+	}
+	writeRows(writer, formatDirectoryTree(root))
+	return nil
+}
+
+func generateDay8(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	row := make([]byte, size)
+	for idx := 0; idx < size; idx++ {
+		for jdx := range row {
+			row[jdx] = byte('0' + rng.Intn(10))
+		}
+		writeRow(writer, string(row))
+	}
+	return nil
+}
+
+func generateDay9(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	for motion := 0; motion < size; motion++ {
+		writeRow(writer, SnakeInstruction{direction: Direction(rng.Intn(4)), steps: 1 + rng.Intn(20)}.String())
+	}
+	return nil
+}
+
+func generateDay10(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	for idx := 0; idx < size; idx++ {
+		instruction := Instruction{operation: NOOP}
+		if rng.Intn(3) > 0 {
+			instruction = Instruction{operation: ADDX, value: rng.Intn(31) - 15}
+		}
+		writeRow(writer, instruction.String())
+	}
+	return nil
+}
+
+// overflowsInFirstRounds plays the rounds of part 1, which divides the worry
+// levels instead of taking a remainder, and reports whether a worry level
+// outgrows 64 bits on the way.
+func overflowsInFirstRounds(monkeys []Monkey) bool {
+	holding := make([][]uint64, len(monkeys))
+	for idx, monkey := range monkeys {
+		holding[idx] = append([]uint64{}, monkey.items...)
+	}
+	for round := 0; round < 20; round++ {
+		for idx, monkey := range monkeys {
+			for _, item := range holding[idx] {
+				var carry, worry uint64
+				switch monkey.worryOperation {
+				case PLUS:
+					carry, worry = bits.Add64(item, monkey.worryValueModifier, 0)
+				case MULTIPLY:
+					carry, worry = bits.Mul64(item, monkey.worryValueModifier)
+				case SQUARED:
+					carry, worry = bits.Mul64(item, item)
+				}
+				if carry != 0 {
+					return true
+				}
+				worry /= 3
+
+				if worry%monkey.testValue == 0 {
+					holding[monkey.throwToMonkeyTrue] = append(holding[monkey.throwToMonkeyTrue], worry)
+				} else {
+					holding[monkey.throwToMonkeyFalse] = append(holding[monkey.throwToMonkeyFalse], worry)
+				}
+			}
+			holding[idx] = nil
+		}
+	}
+	return false
+}
+
+// generateDay11 uses a different prime for every monkey, their product is
+// small enough that squaring an item below it does not overflow. Monkeys that
+// would overflow in part 1 are thrown away and drawn again.
+func generateDay11(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	primes := []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23}
+	for {
+		rng.Shuffle(len(primes), func(i, j int) { primes[i], primes[j] = primes[j], primes[i] })
+
+		monkeys := make([]Monkey, 8)
+		for idx := range monkeys {
+			throwToMonkeyTrue := rng.Intn(len(monkeys) - 1)
+			if throwToMonkeyTrue >= idx {
+				throwToMonkeyTrue++
+			}
+			throwToMonkeyFalse := rng.Intn(len(monkeys) - 1)
+			if throwToMonkeyFalse >= idx {
+				throwToMonkeyFalse++
+			}
+			monkeys[idx] = Monkey{
+				monkeyId:           uint64(idx),
+				items:              []uint64{},
+				worryOperation:     WorryOperation(rng.Intn(2)),
+				worryValueModifier: uint64(1 + rng.Intn(9)),
+				testValue:          primes[idx],
+				throwToMonkeyTrue:  uint64(throwToMonkeyTrue),
+				throwToMonkeyFalse: uint64(throwToMonkeyFalse),
+			}
+		}
+		monkeys[rng.Intn(len(monkeys))].worryOperation = SQUARED
+
+		for item := 0; item < size; item++ {
+			monkey := &monkeys[rng.Intn(len(monkeys))]
+			monkey.items = append(monkey.items, uint64(50+rng.Intn(50)))
+		}
+		for idx := range monkeys {
+			if len(monkeys[idx].items) == 0 {
+				monkeys[idx].items = []uint64{uint64(50 + rng.Intn(50))}
+			}
+		}
+
+		if !overflowsInFirstRounds(monkeys) {
+			writeRows(writer, formatMonkeys(monkeys))
+			return nil
+		}
+	}
+}
+
+// generateDay12 climbs from S along the top row and down the right column,
+// one letter at the time, the rest of the map is random. The map is written
+// row by row, the path is worked out from the position.
+func generateDay12(rng *rand.Rand, size int, writer *bufio.Writer) error {
+	if size < 14 {
+		return fmt.Errorf("day 12 needs a size of at least 14 to climb from a to z, got %d", size)
+	}
+	pathLength := 2*size - 1
+	row := make([]byte, size)
+	for idx := 0; idx < size; idx++ {
+		for jdx := range row {
+			switch {
+			case idx == 0:
+				row[jdx] = lowercaseLetters[jdx*25/(pathLength-1)]
+			case jdx == size-1:
+				row[jdx] = lowercaseLetters[(size-1+idx)*25/(pathLength-1)]
+			default:
+				row[jdx] = lowercaseLetters[rng.Intn(len(lowercaseLetters))]
+			}
+		}
+		if idx == 0 {
+			row[0] = 'S'
+		}
+		if idx == size-1 {
+			row[size-1] = 'E'
+		}
+		writeRow(writer, string(row))
+	}
+	return nil
+}
+
+func genCommand(args []string) {
+	flagSet := flag.NewFlagSet("gen", flag.ExitOnError)
+	day := flagSet.Int("day", 0, "day to generate an input for")
+	seed := flagSet.Int64("seed", 1, "seed for the random generator, the same seed gives the same input")
+	size := flagSet.Int("size", 1000, "size of the input, see the list below")
+	output := flagSet.String("output", "-", "file to write the input to, - for stdout")
+	flagSet.Usage = func() {
+		fmt.Fprintf(flagSet.Output(), "Usage of gen:\n")
+		flagSet.PrintDefaults()
+		fmt.Fprintf(flagSet.Output(), "\nThe size is the number of\n")
+		for generatorDay := 1; generatorDay <= len(generators); generatorDay++ {
+			fmt.Fprintf(flagSet.Output(), "  day %2d: %s\n", generatorDay, generatorSizes[generatorDay])
+		}
+	}
+	flagSet.Parse(args)
+
+	generator, ok := generators[*day]
+	if !ok {
+		log.Fatalf("no generator for day %d", *day)
+	}
+	if *size < 1 {
+		log.Fatalf("size has to be at least 1, got %d", *size)
+	}
+
+	file := os.Stdout
+	if *output != "-" {
+		var err error
+		file, err = os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+	}
+	writer := bufio.NewWriter(file)
+	if err := generator(rand.New(rand.NewSource(*seed)), *size, writer); err != nil {
+		log.Fatal(err)
+	}
+	if err := writer.Flush(); err != nil {
+		log.Fatal(err)
+	}
+}
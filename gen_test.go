@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// generateRows runs the generator into memory, the tests work on the rows.
+func generateRows(generator Generator, rng *rand.Rand, size int) ([]string, error) {
+	var buffer bytes.Buffer
+	writer := bufio.NewWriter(&buffer)
+	if err := generator(rng, size, writer); err != nil {
+		return nil, err
+	}
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n"), nil
+}
+
+func TestGenerators(t *testing.T) {
+	for _, key := range getSortedSolverKeys() {
+		t.Run(key.String(), func(t *testing.T) {
+			if key == (SolverKey{12, 2}) {
+				t.Skip("day 12 part 2 is not solved yet")
+			}
+			rows, err := generateRows(generators[key.day], rand.New(rand.NewSource(7)), 50)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, err := range linters[key.day](newPuzzleInput([]byte(strings.Join(rows, "\n")))) {
+				t.Errorf("lint: %v", err)
+			}
+			source := newReaderInputSource("generated", strings.NewReader(strings.Join(rows, "\n")+"\n"))
+			if _, err := runSolver(key, source); err != nil {
+				t.Errorf("solving the generated input failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestGeneratorsAreReproducible(t *testing.T) {
+	for day, generator := range generators {
+		first, err := generateRows(generator, rand.New(rand.NewSource(3)), 20)
+		if err != nil {
+			t.Fatalf("day %d: %v", day, err)
+		}
+		second, _ := generateRows(generator, rand.New(rand.NewSource(3)), 20)
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("day %d gave different inputs for the same seed", day)
+		}
+	}
+}
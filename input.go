@@ -0,0 +1,311 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed input*.txt
+var embeddedInputs embed.FS
+
+// InputSource opens the puzzle input for a day, Name is used when reporting
+// problems with the input.
+type InputSource interface {
+	Open(day int) (io.ReadCloser, error)
+	Name(day int) string
+}
+
+// DefaultInputSource follows the inputN.txt naming, a file in the working
+// directory takes priority over the copy embedded in the binary.
+type DefaultInputSource struct{}
+
+func (DefaultInputSource) Open(day int) (io.ReadCloser, error) {
+	file, err := os.Open(getInputFileName(day))
+	if errors.Is(err, fs.ErrNotExist) {
+		return embeddedInputs.Open(getInputFileName(day))
+	}
+	return file, err
+}
+
+func (DefaultInputSource) Name(day int) string {
+	return getInputFileName(day)
+}
+
+// FileInputSource uses the same file for every day.
+type FileInputSource struct {
+	path string
+}
+
+func (f FileInputSource) Open(day int) (io.ReadCloser, error) {
+	return os.Open(f.path)
+}
+
+func (f FileInputSource) Name(day int) string {
+	return f.path
+}
+
+// ReaderInputSource hands out a piped input. Read by a single solver, the
+// reader is streamed as it is. Buffered, the reader is read once and the data
+// is handed out to every Open, so several parts can be solved from the same
+// input, at the cost of holding it in memory.
+type ReaderInputSource struct {
+	name     string
+	reader   io.Reader
+	buffered bool
+	mutex    sync.Mutex
+	opened   bool
+	data     []byte
+	err      error
+}
+
+func newReaderInputSource(name string, reader io.Reader) *ReaderInputSource {
+	return &ReaderInputSource{name: name, reader: reader}
+}
+
+func newBufferedReaderInputSource(name string, reader io.Reader) *ReaderInputSource {
+	return &ReaderInputSource{name: name, reader: reader, buffered: true}
+}
+
+func (r *ReaderInputSource) Open(day int) (io.ReadCloser, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.buffered {
+		if r.opened {
+			return nil, fmt.Errorf("%s can only be read once", r.name)
+		}
+		r.opened = true
+		return io.NopCloser(r.reader), nil
+	}
+
+	if !r.opened {
+		r.data, r.err = io.ReadAll(r.reader)
+		r.opened = true
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return io.NopCloser(bytes.NewReader(r.data)), nil
+}
+
+func (r *ReaderInputSource) Name(day int) string {
+	return r.name
+}
+
+// newInputSource maps the -input flag to a source, "-" reads stdin and an
+// empty value falls back to inputN.txt. Stdin is only buffered when it is
+// opened more than once.
+func newInputSource(input string, opens int) InputSource {
+	switch input {
+	case "":
+		return DefaultInputSource{}
+	case "-":
+		if opens > 1 {
+			return newBufferedReaderInputSource("<stdin>", os.Stdin)
+		}
+		return newReaderInputSource("<stdin>", os.Stdin)
+	default:
+		return FileInputSource{path: input}
+	}
+}
+
+// PuzzleInput is the normalized text of an input, without a byte order mark,
+// with CRLF turned into LF and without the trailing newlines. A carriage
+// return ending the last row is dropped as well, the same as bufio.ScanLines
+// does for LineScanner.
+type PuzzleInput struct {
+	text string
+}
+
+// Paragraph is a group of rows between empty rows, line is the 1-based line
+// number of its first row.
+type Paragraph struct {
+	line int
+	rows []string
+}
+
+func newPuzzleInput(data []byte) *PuzzleInput {
+	text := strings.TrimPrefix(string(data), "\uFEFF")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\r")
+	text = strings.TrimRight(text, "\n")
+	return &PuzzleInput{text: text}
+}
+
+func readPuzzleInput(reader io.Reader) (*PuzzleInput, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return newPuzzleInput(data), nil
+}
+
+func (p *PuzzleInput) Bytes() []byte {
+	return []byte(p.text)
+}
+
+func (p *PuzzleInput) Lines() []string {
+	if p.text == "" {
+		return []string{}
+	}
+	return strings.Split(p.text, "\n")
+}
+
+// Paragraphs splits the lines on empty rows, repeated empty rows do not
+// produce empty paragraphs.
+func (p *PuzzleInput) Paragraphs() []Paragraph {
+	paragraphs := make([]Paragraph, 0)
+	current := Paragraph{line: 1}
+	for idx, row := range p.Lines() {
+		if row == "" {
+			if len(current.rows) > 0 {
+				paragraphs = append(paragraphs, current)
+			}
+			current = Paragraph{line: idx + 2}
+			continue
+		}
+		current.rows = append(current.rows, row)
+	}
+	if len(current.rows) > 0 {
+		paragraphs = append(paragraphs, current)
+	}
+	return paragraphs
+}
+
+// maxLineLength is the longest row a LineScanner accepts, set through -max-line.
+var maxLineLength = 1 << 20
+
+// LineScanner streams the same rows as PuzzleInput.Lines without holding the
+// input in memory. Empty rows are held back until a non-empty row follows, so
+// trailing empty rows are dropped.
+type LineScanner struct {
+	scanner       *bufio.Scanner
+	maxLineLength int
+	started       bool
+	line          int
+	row           string
+	blanks        int
+	next          string
+	hasNext       bool
+}
+
+func newLineScanner(reader io.Reader, maxLineLength int) *LineScanner {
+	scanner := bufio.NewScanner(reader)
+	initialSize := bufio.MaxScanTokenSize
+	if maxLineLength < initialSize {
+		initialSize = maxLineLength
+	}
+	// the scanner needs room for the newline and a possible carriage return
+	scanner.Buffer(make([]byte, 0, initialSize), maxLineLength+2)
+	return &LineScanner{scanner: scanner, maxLineLength: maxLineLength}
+}
+
+func (l *LineScanner) Scan() bool {
+	if l.blanks > 0 {
+		l.blanks--
+		l.row = ""
+		l.line++
+		return true
+	}
+	if l.hasNext {
+		l.hasNext = false
+		l.row = l.next
+		l.line++
+		return true
+	}
+
+	for l.scanner.Scan() {
+		// ScanLines already dropped the carriage return of a CRLF
+		row := l.scanner.Text()
+		if !l.started {
+			row = strings.TrimPrefix(row, "\uFEFF")
+			l.started = true
+		}
+		if row == "" {
+			l.blanks++
+			continue
+		}
+		if l.blanks > 0 {
+			l.blanks--
+			l.next, l.hasNext = row, true
+			row = ""
+		}
+		l.row = row
+		l.line++
+		return true
+	}
+	return false
+}
+
+// Text returns the current row and Line its 1-based line number.
+func (l *LineScanner) Text() string {
+	return l.row
+}
+
+func (l *LineScanner) Line() int {
+	return l.line
+}
+
+func (l *LineScanner) Err() error {
+	err := l.scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		return fmt.Errorf("line %d is longer than %d bytes: %w", l.line+l.blanks+1, l.maxLineLength, err)
+	}
+	return err
+}
+
+func readInput(source InputSource, day int) ([]byte, error) {
+	reader, err := source.Open(day)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// getEmbeddedInputName accepts either a file name (input7.txt) or a day (7).
+func getEmbeddedInputName(nameOrDay string) string {
+	if day, err := strconv.Atoi(nameOrDay); err == nil {
+		return getInputFileName(day)
+	}
+	return nameOrDay
+}
+
+func inputsCommand(args []string) {
+	flagSet := flag.NewFlagSet("inputs", flag.ExitOnError)
+	flagSet.Usage = func() {
+		fmt.Fprintln(flagSet.Output(), "usage: inputs [input file or day to print]")
+	}
+	flagSet.Parse(args)
+
+	if flagSet.NArg() == 0 {
+		entries, err := embeddedInputs.ReadDir(".")
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s\t%d bytes\n", entry.Name(), info.Size())
+		}
+		return
+	}
+
+	data, err := embeddedInputs.ReadFile(getEmbeddedInputName(flagSet.Arg(0)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(data)
+}
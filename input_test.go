@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// scanLines collects the rows and line numbers of a LineScanner.
+func scanLines(t *testing.T, input string, maxLineLength int) ([]string, []int, error) {
+	t.Helper()
+	lines := newLineScanner(strings.NewReader(input), maxLineLength)
+	rows, numbers := []string{}, []int{}
+	for lines.Scan() {
+		rows = append(rows, lines.Text())
+		numbers = append(numbers, lines.Line())
+	}
+	return rows, numbers, lines.Err()
+}
+
+func TestPuzzleInputLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"empty", "", []string{}},
+		{"only newlines", "\n\n\r\n", []string{}},
+		{"trailing newline", "a\nb\n", []string{"a", "b"}},
+		{"trailing newlines", "a\nb\n\n\n", []string{"a", "b"}},
+		{"crlf", "a\r\nb\r\n", []string{"a", "b"}},
+		{"mixed endings", "a\r\nb\nc", []string{"a", "b", "c"}},
+		{"bare carriage return at the end", "a\r", []string{"a"}},
+		{"carriage return inside a row", "a\rb\n", []string{"a\rb"}},
+		{"byte order mark", "\uFEFFa\nb", []string{"a", "b"}},
+		{"byte order mark with crlf", "\uFEFFa\r\n\r\nb\r\n", []string{"a", "", "b"}},
+		{"blank runs", "a\n\n\nb\n\nc", []string{"a", "", "", "b", "", "c"}},
+		{"leading blank rows", "\n\na", []string{"", "", "a"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := newPuzzleInput([]byte(test.input)).Lines(); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Lines() = %q, want %q", got, test.want)
+			}
+			got, _, err := scanLines(t, test.input, maxLineLength)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("LineScanner rows = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPuzzleInputParagraphs(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []Paragraph
+	}{
+		{"", []Paragraph{}},
+		{"a\nb", []Paragraph{{1, []string{"a", "b"}}}},
+		{"a\n\nb\nc\n", []Paragraph{{1, []string{"a"}}, {3, []string{"b", "c"}}}},
+		{"\n\na\n\n\n\nb", []Paragraph{{3, []string{"a"}}, {7, []string{"b"}}}},
+		{"\uFEFFa\r\n\r\nb\r\n\r\n", []Paragraph{{1, []string{"a"}}, {3, []string{"b"}}}},
+	}
+	for _, test := range tests {
+		if got := newPuzzleInput([]byte(test.input)).Paragraphs(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Paragraphs() of %q = %v, want %v", test.input, got, test.want)
+		}
+	}
+}
+
+// TestLineScannerAgreesWithPuzzleInput checks that holding back the blank
+// rows gives the same rows and line numbers as splitting the whole input.
+func TestLineScannerAgreesWithPuzzleInput(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	pieces := []string{"a", "bc", "\n", "\n", "\r\n", "\r", "\uFEFF"}
+	for iteration := 0; iteration < 2000; iteration++ {
+		var input strings.Builder
+		for idx := rng.Intn(12); idx > 0; idx-- {
+			input.WriteString(pieces[rng.Intn(len(pieces))])
+		}
+
+		want := newPuzzleInput([]byte(input.String())).Lines()
+		got, numbers, err := scanLines(t, input.String(), maxLineLength)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("rows of %q = %q, want %q", input.String(), got, want)
+		}
+		for idx, number := range numbers {
+			if number != idx+1 {
+				t.Fatalf("row %d of %q has Line() %d", idx+1, input.String(), number)
+			}
+		}
+	}
+}
+
+func TestLineScannerTooLongLine(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"abcdefgh", "line 1 is longer than 4 bytes"},
+		{"ab\nabcdefgh\nab", "line 2 is longer than 4 bytes"},
+		{"ab\n\n\nabcdefgh", "line 4 is longer than 4 bytes"},
+	}
+	for _, test := range tests {
+		_, _, err := scanLines(t, test.input, 4)
+		if !errors.Is(err, bufio.ErrTooLong) || !strings.HasPrefix(err.Error(), test.want) {
+			t.Errorf("Err() of %q = %v, want %q", test.input, err, test.want)
+		}
+	}
+}
+
+func TestReaderInputSource(t *testing.T) {
+	streamed := newReaderInputSource("<stdin>", strings.NewReader("a\nb"))
+	if _, err := streamed.Open(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := streamed.Open(1); err == nil {
+		t.Error("a streamed source opened twice, want an error")
+	}
+
+	buffered := newBufferedReaderInputSource("<stdin>", strings.NewReader("a\nb"))
+	for open := 0; open < 2; open++ {
+		data, err := readInput(buffered, 1)
+		if err != nil || string(data) != "a\nb" {
+			t.Errorf("open %d gave %q, %v, want \"a\\nb\"", open+1, data, err)
+		}
+	}
+}
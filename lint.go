@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+// Linter checks a whole input against the grammar of a day and returns every
+// problem, where the solvers stop at the first one.
+type Linter func(input *PuzzleInput) []error
+
+var linters = map[int]Linter{
+	1:  lintDay1,
+	2:  lintDay2,
+	3:  lintDay3,
+	4:  lintDay4,
+	5:  lintDay5,
+	6:  lintDay6,
+	7:  lintDay7,
+	8:  lintDay8,
+	9:  lintDay9,
+	10: lintDay10,
+	11: lintDay11,
+	12: lintDay12,
+}
+
+// lintRows checks every row on its own, check gets the 1-based line.
+func lintRows(rows []string, check func(line int, row string) error) []error {
+	var errs []error
+	for idx, row := range rows {
+		if err := check(idx+1, row); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// getSelectedDays returns every day once, in order.
+func getSelectedDays(keys []SolverKey) []int {
+	days := []int{}
+	for _, key := range keys {
+		if len(days) == 0 || days[len(days)-1] != key.day {
+			days = append(days, key.day)
+		}
+	}
+	return days
+}
+
+func lintCommand(args []string) {
+	flagSet := flag.NewFlagSet("lint", flag.ExitOnError)
+	selection := addSelectionFlags(flagSet)
+	input := addInputFlag(flagSet)
+	addGameFlag(flagSet)
+	flagSet.Parse(args)
+
+	if *selection.day == "" {
+		*selection.all = true
+	}
+	selected, err := selection.selectSolvers()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source := newInputSource(*input, len(getSelectedDays(selected)))
+	problems := 0
+	for _, day := range getSelectedDays(selected) {
+		data, err := readInput(source, day)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		errs := linters[day](newPuzzleInput(data))
+		for _, err := range errs {
+			err = setParseErrorFile(err, source.Name(day))
+			var parseErr *ParseError
+			if errors.As(err, &parseErr) {
+				fmt.Println(parseErr.Diagnostic())
+			} else {
+				fmt.Println(err)
+			}
+		}
+		switch len(errs) {
+		case 0:
+			fmt.Printf("day %d: ok\n", day)
+		case 1:
+			fmt.Printf("day %d: 1 problem\n", day)
+		default:
+			fmt.Printf("day %d: %d problems\n", day, len(errs))
+		}
+		problems += len(errs)
+	}
+
+	if problems > 0 {
+		os.Exit(1)
+	}
+}
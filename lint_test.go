@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLinters(t *testing.T) {
+	tests := []struct {
+		day   int
+		input string
+		want  []string
+	}{
+		{1, "1000\n2000\n\nabc\n3000\n4x", []string{"4:1", "6:1"}},
+		{2, "A X\nD Y\nB Z\nC W", []string{"2:1", "4:3"}},
+		{3, "vJrwpWtwJgWrhcsFMMfFFhFp\nabc\nab1c", []string{"2:4", "3:3"}},
+		{4, "2-4,6-8\n4-2,1-3\n1-3,8-6\n1-2", []string{"2:1", "3:5", "4:1"}},
+		{5, "    [D]\n[N] [C]\n 1   2\n\nmove 3 from 1 to 2\nmove 1 from 3 to 1\nmove 1 from 1 to 2\nmove 1 from 1 to 2", []string{"5:6", "6:13", "8:6"}},
+		{5, "[A]\n 1 ", []string{}},
+		{6, "abcD1\nabc", []string{"1:4", "1:5", "2:1"}},
+		{7, "$ cd /\n$ ls\ndir a\n$ cd b\n$ cd a\nx y\n$ cd ..\n$ cd ..", []string{"4:6", "6:1", "8:6"}},
+		{8, "123\n4x6\n78", []string{"2:2", "3:1"}},
+		{9, "R 4\nX 1\nU -1", []string{"2:1", "3:3"}},
+		{10, "noop\naddx\naddx 3\nmul 2", []string{"2:1", "4:1"}},
+		{11, "" +
+			"Monkey 0:\n  Starting items: 79, x\n  Operation: new = old * 19\n  Test: divisible by 0\n    If true: throw to monkey 1\n    If false: throw to monkey 1\n\n" +
+			"Monkey 1:\n  Starting items: 54\n  Operation: new = old + 6\n  Test: divisible by 19\n    If true: throw to monkey 0\n    If false: throw to monkey 2",
+			[]string{"2:23", "4:22", "13:31"}},
+		{12, "SabE\nab1\nSE", []string{"2:1", "2:3", "3:1", "3:1", "3:2"}},
+	}
+
+	for _, test := range tests {
+		errs := linters[test.day](newPuzzleInput([]byte(test.input)))
+		got := make([]string, len(errs))
+		for idx, err := range errs {
+			// keep line:column from "<input>:line:column: ..."
+			fields := strings.SplitN(err.Error(), ":", 4)
+			got[idx] = fields[1] + ":" + fields[2]
+		}
+		if strings.Join(got, " ") != strings.Join(test.want, " ") {
+			t.Errorf("day %d: problems at %v, want %v\n%v", test.day, got, test.want, errs)
+		}
+	}
+}
+
+func TestLintersAcceptPuzzleInputs(t *testing.T) {
+	for day, linter := range linters {
+		data, err := embeddedInputs.ReadFile(getInputFileName(day))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, err := range linter(newPuzzleInput(data)) {
+			t.Errorf("day %d: %v", day, err)
+		}
+	}
+}
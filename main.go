@@ -1,18 +1,17 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"io/ioutil"
-	"runtime"
+	"log"
+	"os"
+	"strconv"
 	"strings"
 	stdTime "time"
 )
 
-func getFunctionName() string {
-	pc, _, _, _ := runtime.Caller(1)
-	return runtime.FuncForPC(pc).Name()
-}
-
 func readFromFile(filename string) (string, error) {
 	read, err := ioutil.ReadFile(filename)
 	if err != nil {
@@ -22,42 +21,164 @@ func readFromFile(filename string) (string, error) {
 	return string(read), nil
 }
 
-func getRowsFromFile(filename string) ([]string, error) {
-	file, err := readFromFile(filename)
+// parseDayRange accepts a single day ("7") or an inclusive range ("3-9").
+func parseDayRange(dayString string) (int, int, error) {
+	bounds := strings.SplitN(dayString, "-", 2)
+	first, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid day %q", dayString)
+	}
+	last := first
+	if len(bounds) == 2 {
+		last, err = strconv.Atoi(bounds[1])
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid day range %q", dayString)
+		}
+	}
+	if first < 1 || last < first {
+		return 0, 0, fmt.Errorf("invalid day range %q", dayString)
+	}
+	return first, last, nil
+}
+
+func hasSolverForDay(day int) bool {
+	for key := range registry {
+		if key.day == day {
+			return true
+		}
+	}
+	return false
+}
+
+type SelectionFlags struct {
+	all  *bool
+	day  *string
+	part *int
+}
+
+func addSelectionFlags(flagSet *flag.FlagSet) *SelectionFlags {
+	return &SelectionFlags{
+		all:  flagSet.Bool("all", false, "run every day and part"),
+		day:  flagSet.String("day", "", "day to run, either a single day (7) or a range (3-9)"),
+		part: flagSet.Int("part", 0, "part to run (1 or 2), 0 runs both"),
+	}
+}
+
+func addInputFlag(flagSet *flag.FlagSet) *string {
+	flagSet.IntVar(&maxLineLength, "max-line", maxLineLength, "longest input row in bytes for the streaming days")
+	return flagSet.String("input", "", "input file for the selected days, defaults to inputN.txt. - reads stdin, which is streamed for a single part and held in memory when several parts read it")
+}
+
+func (s *SelectionFlags) selectSolvers() ([]SolverKey, error) {
+	return selectSolvers(*s.all, *s.day, *s.part)
+}
+
+func selectSolvers(all bool, dayString string, part int) ([]SolverKey, error) {
+	if part < 0 || part > 2 {
+		return nil, fmt.Errorf("invalid part %d, expected 1 or 2", part)
+	}
+	if all {
+		dayString = ""
+	} else if dayString == "" {
+		return nil, errors.New("no day selected, use -day or -all")
+	}
+
+	keys := getSortedSolverKeys()
+	first, last := 1, keys[len(keys)-1].day
+	if dayString != "" {
+		var err error
+		first, last, err = parseDayRange(dayString)
+		if err != nil {
+			return nil, err
+		}
+		for day := first; day <= last; day++ {
+			if !hasSolverForDay(day) {
+				return nil, fmt.Errorf("no solver for day %d", day)
+			}
+		}
+	}
+
+	selected := make([]SolverKey, 0)
+	for _, key := range keys {
+		if key.day < first || key.day > last {
+			continue
+		}
+		if part != 0 && key.part != part {
+			continue
+		}
+		selected = append(selected, key)
+	}
+	return selected, nil
+}
+
+func runCommand(args []string) {
+	flagSet := flag.NewFlagSet("run", flag.ExitOnError)
+	selection := addSelectionFlags(flagSet)
+	parallel := flagSet.Int("parallel", 1, "number of solvers to run at the same time")
+	formatString := flagSet.String("format", "text", "output format: text, json or csv")
+	input := addInputFlag(flagSet)
+	watch := flagSet.Bool("watch", false, "re-run the selected days whenever their input files change")
+	pollInterval := flagSet.Duration("poll", 500*stdTime.Millisecond, "how often -watch checks the input files")
+	addGameFlag(flagSet)
+	addProfileFlags(flagSet)
+	flagSet.Parse(args)
+
+	if solverProfiles.enabled() && *parallel > 1 {
+		log.Fatal("the profiles cover the whole process, so they can not be combined with -parallel")
+	}
+
+	format, err := parseOutputFormat(*formatString)
 	if err != nil {
-		return nil, err
+		log.Fatal(err)
 	}
+	selected, err := selection.selectSolvers()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source := newInputSource(*input, len(selected))
+	run := func() {
+		time := stdTime.Now()
+		results := runSolvers(selected, source, *parallel)
+		if err := writeResults(os.Stdout, format, results); err != nil {
+			log.Fatal(err)
+		}
+
+		if format == TextFormat {
+			duration := stdTime.Since(time)
+			fmt.Println("Duration: ", duration)
+		}
+	}
+
+	if *watch {
+		log.Fatal(watchSolvers(selected, source, *pollInterval, run))
+	}
+	run()
+}
 
-	return strings.Split(file, "\n"), nil
+var commands = map[string]func(args []string){
+	"run":      runCommand,
+	"bench":    benchCommand,
+	"verify":   verifyCommand,
+	"inputs":   inputsCommand,
+	"serve":    serveCommand,
+	"gen":      genCommand,
+	"lint":     lintCommand,
+	"stats":    statsCommand,
+	"strategy": strategyCommand,
 }
 
+// main dispatches to a command, "run" is used when the first argument is a flag.
 func main() {
-	time := stdTime.Now()
-	// day1_part1()
-	// day1_part2()
-	// day2_part1()
-	// day2_part2()
-	// day3_part1()
-	// day3_part2()
-	// day4_part1()
-	// day4_part2()
-	// day5_part1()
-	// day5_part2()
-	// day6_part1()
-	// day6_part2()
-	// day7_part1()
-	// day7_part2()
-	// day8_part1()
-	// day8_part2()
-	// day9_part1()
-    // day9_part2()
-	// day10_part1()
-	// day10_part2()
-	// day11_part1()
-	// day11_part2()
-    day12_part1()
-    day12_part2()
-
-	duration := stdTime.Since(time)
-	fmt.Println("Duration: ", duration)
+	command := "run"
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	run, ok := commands[command]
+	if !ok {
+		log.Fatalf("unknown command %q", command)
+	}
+	run(args)
 }
@@ -1,12 +0,0 @@
-package main
-
-import (
-	"fmt"
-	"testing"
-)
-
-func TestPart1(t *testing.T) {
-	fmt.Println("Test")
-    i := 20
-    fmt.Println("Test", i)
-}
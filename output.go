@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type OutputFormat string
+
+const (
+	TextFormat OutputFormat = "text"
+	JSONFormat OutputFormat = "json"
+	CSVFormat  OutputFormat = "csv"
+)
+
+func parseOutputFormat(format string) (OutputFormat, error) {
+	switch OutputFormat(format) {
+	case TextFormat, JSONFormat, CSVFormat:
+		return OutputFormat(format), nil
+	default:
+		return "", fmt.Errorf("unknown output format %q, expected text, json or csv", format)
+	}
+}
+
+// ResultRecord is the machine readable form of a SolverResult, the answer is
+// always written as the text from Answer.String and typed through kind.
+type ResultRecord struct {
+	Day        int    `json:"day"`
+	Part       int    `json:"part"`
+	Kind       string `json:"kind,omitempty"`
+	Answer     string `json:"answer,omitempty"`
+	DurationNs int64  `json:"duration_ns"`
+	Error      string `json:"error,omitempty"`
+}
+
+func newResultRecord(result SolverResult) ResultRecord {
+	record := ResultRecord{Day: result.key.day, Part: result.key.part, DurationNs: result.duration.Nanoseconds()}
+	if result.err != nil {
+		record.Error = result.err.Error()
+	} else {
+		record.Kind = result.answer.Kind().String()
+		record.Answer = result.answer.String()
+	}
+	return record
+}
+
+func writeTextResults(writer io.Writer, results []SolverResult) error {
+	for _, result := range results {
+		var err error
+		var parseErr *ParseError
+		if errors.As(result.err, &parseErr) {
+			_, err = fmt.Fprintf(writer, "%v  error:  (%v)\n%s\n", result.key, result.duration, parseErr.Diagnostic())
+		} else if result.err != nil {
+			_, err = fmt.Fprintf(writer, "%v  error:  %v  (%v)\n", result.key, result.err, result.duration)
+		} else if result.answer.Kind() == ImageAnswer {
+			_, err = fmt.Fprintf(writer, "%v  solution:  (%v)\n%v\n", result.key, result.duration, result.answer)
+		} else {
+			_, err = fmt.Fprintf(writer, "%v  solution:  %v  (%v)\n", result.key, result.answer, result.duration)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSONResults writes one JSON object per line.
+func writeJSONResults(writer io.Writer, results []SolverResult) error {
+	encoder := json.NewEncoder(writer)
+	for _, result := range results {
+		if err := encoder.Encode(newResultRecord(result)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSVResults(writer io.Writer, results []SolverResult) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Write([]string{"day", "part", "kind", "answer", "duration_ns", "error"})
+	for _, result := range results {
+		record := newResultRecord(result)
+		csvWriter.Write([]string{
+			strconv.Itoa(record.Day),
+			strconv.Itoa(record.Part),
+			record.Kind,
+			record.Answer,
+			strconv.FormatInt(record.DurationNs, 10),
+			record.Error,
+		})
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func writeResults(writer io.Writer, format OutputFormat, results []SolverResult) error {
+	switch format {
+	case JSONFormat:
+		return writeJSONResults(writer, results)
+	case CSVFormat:
+		return writeCSVResults(writer, results)
+	default:
+		return writeTextResults(writer, results)
+	}
+}
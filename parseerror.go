@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError points at the offending text of a puzzle input, line and column
+// are 1-based. The file is filled in by the runner, which knows the source.
+type ParseError struct {
+	file     string
+	line     int
+	column   int
+	row      string
+	text     string
+	expected string
+}
+
+func newParseError(line int, column int, row string, text string, expected string) *ParseError {
+	return &ParseError{line: line, column: column, row: row, text: text, expected: expected}
+}
+
+// newFieldParseError points at field idx of a row that was split on single spaces.
+func newFieldParseError(line int, row string, fields []string, idx int, expected string) *ParseError {
+	column := 1
+	for _, field := range fields[:idx] {
+		column += len(field) + 1
+	}
+	return newParseError(line, column, row, fields[idx], expected)
+}
+
+func (p *ParseError) Error() string {
+	file := p.file
+	if file == "" {
+		file = "<input>"
+	}
+	if p.text == "" {
+		return fmt.Sprintf("%s:%d:%d: unexpected end of input, expected %s", file, p.line, p.column, p.expected)
+	}
+	return fmt.Sprintf("%s:%d:%d: unexpected %q, expected %s", file, p.line, p.column, p.text, p.expected)
+}
+
+// Diagnostic formats the error like a compiler, with the offending row and a
+// caret under the offending text.
+func (p *ParseError) Diagnostic() string {
+	underline := "^"
+	if len(p.text) > 1 {
+		underline += strings.Repeat("~", len(p.text)-1)
+	}
+	return fmt.Sprintf("%s\n    %s\n    %s%s", p.Error(), p.row, strings.Repeat(" ", p.column-1), underline)
+}
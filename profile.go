@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+)
+
+// Profiles holds the file names given with -cpuprofile, -memprofile and
+// -trace, every solver writes its own copy with the day and part added to the
+// name. An empty name leaves that profile off.
+type Profiles struct {
+	cpu    string
+	memory string
+	trace  string
+}
+
+// solverProfiles is set through the profiling flags of run.
+var solverProfiles Profiles
+
+func addProfileFlags(flagSet *flag.FlagSet) {
+	flagSet.StringVar(&solverProfiles.cpu, "cpuprofile", "", "write a CPU profile of every solver, cpu.prof becomes cpu_day1_part1.prof")
+	flagSet.StringVar(&solverProfiles.memory, "memprofile", "", "write a heap profile of every solver, taken before its input is released")
+	flagSet.StringVar(&solverProfiles.trace, "trace", "", "write an execution trace of every solver")
+}
+
+func (p Profiles) enabled() bool {
+	return p.cpu != "" || p.memory != "" || p.trace != ""
+}
+
+// getProfileFileName adds the day and part in front of the extension.
+func getProfileFileName(name string, key SolverKey) string {
+	extension := filepath.Ext(name)
+	return fmt.Sprintf("%s_day%d_part%d%s", strings.TrimSuffix(name, extension), key.day, key.part, extension)
+}
+
+// profileSolver runs solve with the profiles switched on. The CPU profile and
+// the trace cover the whole process, so only one solver can be profiled at a
+// time. The heap profile is written from inside solve through writeHeap,
+// while the parsed input is still alive.
+func profileSolver(key SolverKey, solve func(writeHeap func() error) error) error {
+	if solverProfiles.cpu != "" {
+		file, err := os.Create(getProfileFileName(solverProfiles.cpu, key))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if err := pprof.StartCPUProfile(file); err != nil {
+			return err
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	if solverProfiles.trace != "" {
+		file, err := os.Create(getProfileFileName(solverProfiles.trace, key))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if err := trace.Start(file); err != nil {
+			return err
+		}
+		defer trace.Stop()
+	}
+
+	writeHeap := func() error {
+		if solverProfiles.memory == "" {
+			return nil
+		}
+		file, err := os.Create(getProfileFileName(solverProfiles.memory, key))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		runtime.GC()
+		return pprof.WriteHeapProfile(file)
+	}
+	if solverProfiles.memory != "" {
+		// start from a clean heap, so the live objects are the solver's
+		runtime.GC()
+	}
+
+	return solve(writeHeap)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfileSolver(t *testing.T) {
+	dir := t.TempDir()
+	solverProfiles = Profiles{
+		cpu:    filepath.Join(dir, "cpu.prof"),
+		memory: filepath.Join(dir, "mem.prof"),
+		trace:  filepath.Join(dir, "trace.out"),
+	}
+	defer func() { solverProfiles = Profiles{} }()
+
+	key := SolverKey{6, 2}
+	if _, err := runSolver(key, FileInputSource{path: filepath.Join("testdata", "day6.txt")}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"cpu_day6_part2.prof", "mem_day6_part2.prof", "trace_day6_part2.out"} {
+		if info, err := os.Stat(filepath.Join(dir, name)); err != nil || info.Size() == 0 {
+			t.Errorf("%s was not written: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// ReferenceSolver is a deliberately naive solver for one part, used to check
+// the real solver on random inputs. It returns an error for inputs the puzzle
+// does not define an answer for.
+type ReferenceSolver func(input *PuzzleInput) (string, error)
+
+var referenceSolvers = map[SolverKey]ReferenceSolver{
+	{1, 1}:  day1_part1_naive,
+	{1, 2}:  day1_part2_naive,
+	{2, 1}:  day2_part1_naive,
+	{2, 2}:  day2_part2_naive,
+	{3, 1}:  day3_part1_naive,
+	{3, 2}:  day3_part2_naive,
+	{4, 1}:  day4_part1_naive,
+	{4, 2}:  day4_part2_naive,
+	{5, 1}:  day5_part1_naive,
+	{5, 2}:  day5_part2_naive,
+	{6, 1}:  day6_part1_naive,
+	{6, 2}:  day6_part2_naive,
+	{7, 1}:  day7_part1_naive,
+	{7, 2}:  day7_part2_naive,
+	{8, 1}:  day8_part1_naive,
+	{8, 2}:  day8_part2_naive,
+	{9, 1}:  day9_part1_naive,
+	{9, 2}:  day9_part2_naive,
+	{10, 1}: day10_part1_naive,
+	{10, 2}: day10_part2_naive,
+	{11, 1}: day11_part1_naive,
+	{11, 2}: day11_part2_naive,
+	{12, 1}: day12_part1_naive,
+}
+
+// solveWithReference runs the reference solver, a panic on a shrunk input
+// counts as an error.
+func solveWithReference(reference ReferenceSolver, rows []string) (answer string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return reference(newPuzzleInput([]byte(strings.Join(rows, "\n"))))
+}
+
+// findMismatch returns both answers when the solvers disagree on the rows.
+// Inputs the reference rejects are not counterexamples, but the real solver
+// has to accept everything the reference does.
+func findMismatch(key SolverKey, reference ReferenceSolver, rows []string) (string, string, bool) {
+	want, err := solveWithReference(reference, rows)
+	if err != nil {
+		return "", "", false
+	}
+	result := runSolverSafely(key, newReaderInputSource("generated", strings.NewReader(strings.Join(rows, "\n"))))
+	got := result.answer.String()
+	if result.err != nil {
+		got = "error: " + result.err.Error()
+	}
+	return got, want, got != want
+}
+
+// shrinkCounterexample first asks the generator for smaller inputs from the
+// same seed, then removes ever smaller chunks of rows for as long as the
+// solvers keep disagreeing.
+func shrinkCounterexample(key SolverKey, reference ReferenceSolver, seed int64, size int, rows []string) []string {
+	for smaller := 1; smaller < size; smaller++ {
+		candidate, err := generateRows(generators[key.day], rand.New(rand.NewSource(seed)), smaller)
+		if err != nil {
+			continue
+		}
+		if _, _, ok := findMismatch(key, reference, candidate); ok {
+			rows = candidate
+			break
+		}
+	}
+
+	for chunk := len(rows) / 2; chunk >= 1; chunk /= 2 {
+		for start := 0; start+chunk <= len(rows); {
+			candidate := append(append([]string{}, rows[:start]...), rows[start+chunk:]...)
+			if _, _, ok := findMismatch(key, reference, candidate); ok {
+				rows = candidate
+			} else {
+				start += chunk
+			}
+		}
+	}
+	return rows
+}
+
+// getReferenceSize picks a small size, small inputs reach the edge cases
+// more often and keep the counterexamples readable.
+func getReferenceSize(rng *rand.Rand, day int) int {
+	if day == 12 {
+		return 14 + rng.Intn(6)
+	}
+	return 1 + rng.Intn(30)
+}
+
+func TestAgainstReferenceSolvers(t *testing.T) {
+	iterations := 100
+	if testing.Short() {
+		iterations = 10
+	}
+
+	for _, key := range getSortedSolverKeys() {
+		reference, ok := referenceSolvers[key]
+		if !ok {
+			continue
+		}
+		t.Run(key.String(), func(t *testing.T) {
+			sizes := rand.New(rand.NewSource(int64(key.day)))
+			for seed := int64(1); seed <= int64(iterations); seed++ {
+				size := getReferenceSize(sizes, key.day)
+				rows, err := generateRows(generators[key.day], rand.New(rand.NewSource(seed)), size)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, _, ok := findMismatch(key, reference, rows); !ok {
+					continue
+				}
+
+				rows = shrinkCounterexample(key, reference, seed, size, rows)
+				got, want, _ := findMismatch(key, reference, rows)
+				t.Fatalf("seed %d size %d: got %q, reference gives %q on\n%s", seed, size, got, want, strings.Join(rows, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type SolverResult struct {
+	key      SolverKey
+	answer   Answer
+	duration time.Duration
+	err      error
+}
+
+// runSolverSafely turns a panicking solver into an error result, so one
+// broken day does not take down the rest of the run.
+func runSolverSafely(key SolverKey, source InputSource) (result SolverResult) {
+	result.key = key
+	start := time.Now()
+	defer func() {
+		result.duration = time.Since(start)
+		if r := recover(); r != nil {
+			result.err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	result.answer, result.err = runSolver(key, source)
+	return result
+}
+
+// runSolvers runs the solvers on a pool of parallel workers and returns the
+// results in the same order as keys.
+func runSolvers(keys []SolverKey, source InputSource, parallel int) []SolverResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	results := make([]SolverResult, len(keys))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < parallel; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				results[idx] = runSolverSafely(keys[idx], source)
+			}
+		}()
+	}
+
+	for idx := range keys {
+		indexes <- idx
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
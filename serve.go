@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SolverRecord struct {
+	Day  int `json:"day"`
+	Part int `json:"part"`
+}
+
+type ErrorRecord struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(writer http.ResponseWriter, status int, value any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		log.Println("writing response: ", err)
+	}
+}
+
+func writeJSONError(writer http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(writer, status, ErrorRecord{Error: fmt.Sprintf(format, args...)})
+}
+
+// parseSolvePath parses the day and part from /solve/{day}/{part}.
+func parseSolvePath(path string) (SolverKey, error) {
+	parts := strings.Split(strings.TrimPrefix(path, "/solve/"), "/")
+	if len(parts) != 2 {
+		return SolverKey{}, fmt.Errorf("expected /solve/{day}/{part}, got %s", path)
+	}
+	day, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return SolverKey{}, fmt.Errorf("invalid day %q", parts[0])
+	}
+	part, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return SolverKey{}, fmt.Errorf("invalid part %q", parts[1])
+	}
+	return SolverKey{day, part}, nil
+}
+
+func newSolveHandler(maxInputBytes int64) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			writer.Header().Set("Allow", http.MethodPost)
+			writeJSONError(writer, http.StatusMethodNotAllowed, "method %s not allowed", request.Method)
+			return
+		}
+
+		key, err := parseSolvePath(request.URL.Path)
+		if err != nil {
+			writeJSONError(writer, http.StatusBadRequest, "%v", err)
+			return
+		}
+		if _, ok := registry[key]; !ok {
+			writeJSONError(writer, http.StatusNotFound, "no solver for %v", key)
+			return
+		}
+
+		body := http.MaxBytesReader(writer, request.Body, maxInputBytes)
+		result := runSolverSafely(key, newReaderInputSource("<request>", body))
+		status := http.StatusOK
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(result.err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		} else if result.err != nil {
+			status = http.StatusUnprocessableEntity
+		}
+		writeJSON(writer, status, newResultRecord(result))
+	}
+}
+
+func daysHandler(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writer.Header().Set("Allow", http.MethodGet)
+		writeJSONError(writer, http.StatusMethodNotAllowed, "method %s not allowed", request.Method)
+		return
+	}
+
+	solvers := make([]SolverRecord, 0, len(registry))
+	for _, key := range getSortedSolverKeys() {
+		solvers = append(solvers, SolverRecord{Day: key.day, Part: key.part})
+	}
+	writeJSON(writer, http.StatusOK, solvers)
+}
+
+func healthzHandler(writer http.ResponseWriter, request *http.Request) {
+	writeJSON(writer, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func newServeMux(maxInputBytes int64) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/solve/", newSolveHandler(maxInputBytes))
+	mux.HandleFunc("/days", daysHandler)
+	mux.HandleFunc("/healthz", healthzHandler)
+	return mux
+}
+
+func serveCommand(args []string) {
+	flagSet := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flagSet.String("addr", "localhost:8080", "address to listen on")
+	maxInputBytes := flagSet.Int64("max-input", 10<<20, "largest accepted puzzle input in bytes")
+	flagSet.Parse(args)
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newServeMux(*maxInputBytes),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Println("listening on ", *addr)
+	log.Fatal(server.ListenAndServe())
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServeMux(t *testing.T) {
+	server := httptest.NewServer(newServeMux(64))
+	defer server.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+		want   string
+	}{
+		{http.MethodGet, "/healthz", "", http.StatusOK, `"status":"ok"`},
+		{http.MethodGet, "/days", "", http.StatusOK, `{"day":12,"part":2}`},
+		{http.MethodPost, "/days", "", http.StatusMethodNotAllowed, "method POST not allowed"},
+		{http.MethodGet, "/solve/1/1", "", http.StatusMethodNotAllowed, "method GET not allowed"},
+		{http.MethodPost, "/solve/1", "", http.StatusBadRequest, "expected /solve/{day}/{part}"},
+		{http.MethodPost, "/solve/x/1", "", http.StatusBadRequest, `invalid day \"x\"`},
+		{http.MethodPost, "/solve/1/y", "", http.StatusBadRequest, `invalid part \"y\"`},
+		{http.MethodPost, "/solve/13/1", "", http.StatusNotFound, "no solver for day 13 part 1"},
+		{http.MethodPost, "/solve/1/2", "1\n2\n\n3\n\n4", http.StatusOK, `"answer":"10"`},
+		{http.MethodPost, "/solve/1/1", "1\nx", http.StatusUnprocessableEntity, "2:1"},
+		{http.MethodPost, "/solve/1/1", strings.Repeat("1000\n", 20), http.StatusRequestEntityTooLarge, "request body too large"},
+	}
+	for _, test := range tests {
+		request, err := http.NewRequest(test.method, server.URL+test.path, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body json.RawMessage
+		err = json.NewDecoder(response.Body).Decode(&body)
+		response.Body.Close()
+		if err != nil {
+			t.Fatalf("%s %s: %v", test.method, test.path, err)
+		}
+		if response.StatusCode != test.status || !strings.Contains(string(body), test.want) {
+			t.Errorf("%s %s = %d %s, want %d with %s", test.method, test.path, response.StatusCode, body, test.status, test.want)
+		}
+	}
+}
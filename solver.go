@@ -0,0 +1,217 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+type AnswerKind int
+
+const (
+	IntAnswer AnswerKind = iota
+	StringAnswer
+	ImageAnswer
+)
+
+func (k AnswerKind) String() string {
+	return [...]string{"int", "string", "image"}[k]
+}
+
+// Answer is the typed result of a solver, day10 part 2 for instance draws
+// letters on a pixel grid instead of producing a number.
+type Answer struct {
+	kind   AnswerKind
+	number int
+	text   string
+	pixels [][]byte
+}
+
+func newIntAnswer(number int) Answer {
+	return Answer{kind: IntAnswer, number: number}
+}
+
+func newStringAnswer(text string) Answer {
+	return Answer{kind: StringAnswer, text: text}
+}
+
+func newImageAnswer(pixels [][]byte) Answer {
+	return Answer{kind: ImageAnswer, pixels: pixels}
+}
+
+func (a Answer) Kind() AnswerKind {
+	return a.kind
+}
+
+func (a Answer) Int() int {
+	return a.number
+}
+
+func (a Answer) Pixels() [][]byte {
+	return a.pixels
+}
+
+func (a Answer) String() string {
+	switch a.kind {
+	case IntAnswer:
+		return fmt.Sprint(a.number)
+	case StringAnswer:
+		return a.text
+	default:
+		pixelRows := make([]string, len(a.pixels))
+		for idx, pixelRow := range a.pixels {
+			pixelRows[idx] = string(pixelRow)
+		}
+		return strings.Join(pixelRows, "\n")
+	}
+}
+
+// Solver splits a puzzle part into parsing the input and solving the parsed
+// input, so the two can be used and measured separately.
+type Solver interface {
+	Parse(reader io.Reader) (any, error)
+	Solve(input any) (Answer, error)
+}
+
+type solverFuncs[T any] struct {
+	parse func(input *PuzzleInput) (T, error)
+	solve func(input T) (Answer, error)
+}
+
+func (s solverFuncs[T]) Parse(reader io.Reader) (any, error) {
+	input, err := readPuzzleInput(reader)
+	if err != nil {
+		return nil, err
+	}
+	return s.parse(input)
+}
+
+func (s solverFuncs[T]) Solve(input any) (Answer, error) {
+	return s.solve(input.(T))
+}
+
+func newSolver[T any](parse func(input *PuzzleInput) (T, error), solve func(input T) (Answer, error)) Solver {
+	return solverFuncs[T]{parse: parse, solve: solve}
+}
+
+// streamSolver is used by the days that solve while reading the rows, so
+// their memory use does not grow with the input. Parsing only sets up the
+// LineScanner, which means the parse errors surface from Solve.
+type streamSolver struct {
+	solve func(lines *LineScanner) (Answer, error)
+}
+
+func (s streamSolver) Parse(reader io.Reader) (any, error) {
+	return newLineScanner(reader, maxLineLength), nil
+}
+
+func (s streamSolver) Solve(input any) (Answer, error) {
+	return s.solve(input.(*LineScanner))
+}
+
+func newStreamSolver(solve func(lines *LineScanner) (Answer, error)) Solver {
+	return streamSolver{solve: solve}
+}
+
+type SolverKey struct {
+	day  int
+	part int
+}
+
+func (k SolverKey) String() string {
+	return fmt.Sprintf("day %d part %d", k.day, k.part)
+}
+
+var registry = map[SolverKey]Solver{
+	{1, 1}:  newStreamSolver(day1_part1),
+	{1, 2}:  newStreamSolver(day1_part2),
+	{2, 1}:  newStreamSolver(day2_part1),
+	{2, 2}:  newStreamSolver(day2_part2),
+	{3, 1}:  newStreamSolver(day3_part1),
+	{3, 2}:  newStreamSolver(day3_part2),
+	{4, 1}:  newStreamSolver(day4_part1),
+	{4, 2}:  newStreamSolver(day4_part2),
+	{5, 1}:  newSolver(parseDay5, day5_part1),
+	{5, 2}:  newSolver(parseDay5, day5_part2),
+	{6, 1}:  newSolver(parseDay6, day6_part1),
+	{6, 2}:  newSolver(parseDay6, day6_part2),
+	{7, 1}:  newSolver(parseDay7, day7_part1),
+	{7, 2}:  newSolver(parseDay7, day7_part2),
+	{8, 1}:  newSolver(parseDay8, day8_part1),
+	{8, 2}:  newSolver(parseDay8, day8_part2),
+	{9, 1}:  newStreamSolver(day9_part1),
+	{9, 2}:  newStreamSolver(day9_part2),
+	{10, 1}: newStreamSolver(day10_part1),
+	{10, 2}: newStreamSolver(day10_part2),
+	{11, 1}: newSolver(parseDay11, day11_part1),
+	{11, 2}: newSolver(parseDay11, day11_part2),
+	{12, 1}: newSolver(parseDay12, day12_part1),
+	{12, 2}: newSolver(parseDay12, day12_part2),
+}
+
+func getSortedSolverKeys() []SolverKey {
+	keys := make([]SolverKey, 0, len(registry))
+	for key := range registry {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].day != keys[j].day {
+			return keys[i].day < keys[j].day
+		}
+		return keys[i].part < keys[j].part
+	})
+	return keys
+}
+
+func getInputFileName(day int) string {
+	return fmt.Sprintf("input%d.txt", day)
+}
+
+func setParseErrorFile(err error, file string) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) && parseErr.file == "" {
+		parseErr.file = file
+	}
+	return err
+}
+
+func runSolver(key SolverKey, source InputSource) (Answer, error) {
+	solver, ok := registry[key]
+	if !ok {
+		return Answer{}, fmt.Errorf("no solver for %v", key)
+	}
+
+	reader, err := source.Open(key.day)
+	if err != nil {
+		return Answer{}, err
+	}
+	defer reader.Close()
+
+	var answer Answer
+	solve := func(writeHeap func() error) error {
+		input, err := solver.Parse(reader)
+		if err != nil {
+			return setParseErrorFile(err, source.Name(key.day))
+		}
+		answer, err = solver.Solve(input)
+		if err != nil {
+			return setParseErrorFile(err, source.Name(key.day))
+		}
+		err = writeHeap()
+		runtime.KeepAlive(input)
+		return err
+	}
+
+	if solverProfiles.enabled() {
+		err = profileSolver(key, solve)
+	} else {
+		err = solve(func() error { return nil })
+	}
+	if err != nil {
+		return Answer{}, err
+	}
+	return answer, nil
+}
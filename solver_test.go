@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func solveExample(t *testing.T, key SolverKey, file string) Answer {
+	t.Helper()
+	answer, err := runSolver(key, FileInputSource{path: filepath.Join("testdata", file)})
+	if err != nil {
+		t.Fatalf("%v on %s: %v", key, file, err)
+	}
+	return answer
+}
+
+func TestExamples(t *testing.T) {
+	tests := []struct {
+		day  int
+		part int
+		file string
+		want string
+	}{
+		{1, 1, "day1.txt", "24000"},
+		{1, 2, "day1.txt", "45000"},
+		{2, 1, "day2.txt", "15"},
+		{2, 2, "day2.txt", "12"},
+		{3, 1, "day3.txt", "157"},
+		{3, 2, "day3.txt", "70"},
+		{4, 1, "day4.txt", "2"},
+		{4, 2, "day4.txt", "4"},
+		{5, 1, "day5.txt", "CMZ"},
+		{5, 2, "day5.txt", "MCD"},
+		{6, 1, "day6.txt", "7"},
+		{6, 2, "day6.txt", "19"},
+		{7, 1, "day7.txt", "95437"},
+		{7, 2, "day7.txt", "24933642"},
+		{8, 1, "day8.txt", "21"},
+		{8, 2, "day8.txt", "8"},
+		{9, 1, "day9.txt", "13"},
+		{9, 2, "day9.txt", "1"},
+		{9, 2, "day9_larger.txt", "36"},
+		{10, 1, "day10.txt", "13140"},
+		{10, 2, "day10.txt", "" +
+			"##..##..##..##..##..##..##..##..##..##..\n" +
+			"###...###...###...###...###...###...###.\n" +
+			"####....####....####....####....####....\n" +
+			"#####.....#####.....#####.....#####.....\n" +
+			"######......######......######......####\n" +
+			"#######.......#######.......#######....."},
+		{11, 1, "day11.txt", "10605"},
+		{11, 2, "day11.txt", "2713310158"},
+		{12, 1, "day12.txt", "31"},
+		{12, 2, "day12.txt", "29"},
+	}
+
+	for _, test := range tests {
+		key := SolverKey{test.day, test.part}
+		t.Run(key.String()+" "+test.file, func(t *testing.T) {
+			if key == (SolverKey{12, 2}) {
+				t.Skip("day 12 part 2 is not solved yet")
+			}
+			if got := solveExample(t, key, test.file).String(); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// readExample returns an example from testdata, to seed the fuzz targets with.
+func readExample(f *testing.F, file string) string {
+	f.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		f.Fatal(err)
+	}
+	return string(data)
+}
+
+// readEmbeddedInput returns the puzzle input embedded in the binary, so the
+// benchmarks always measure the same input.
+func readEmbeddedInput(b *testing.B, day int) []byte {
+	b.Helper()
+	data, err := embeddedInputs.ReadFile(getInputFileName(day))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+func BenchmarkSolvers(b *testing.B) {
+	for _, key := range getSortedSolverKeys() {
+		solver := registry[key]
+		data := readEmbeddedInput(b, key.day)
+		b.Run(fmt.Sprintf("day%d/part%d", key.day, key.part), func(b *testing.B) {
+			if key == (SolverKey{12, 2}) {
+				b.Skip("day 12 part 2 is not solved yet")
+			}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				input, err := solver.Parse(bytes.NewReader(data))
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := solver.Solve(input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
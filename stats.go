@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+type ElfRecord struct {
+	Elf      int `json:"elf"`
+	Items    int `json:"items"`
+	Calories int `json:"calories"`
+}
+
+func newElfRecord(elf ElfCalories) ElfRecord {
+	return ElfRecord{Elf: elf.elf, Items: elf.items, Calories: elf.calories}
+}
+
+type PercentileRecord struct {
+	Percentile int `json:"percentile"`
+	Calories   int `json:"calories"`
+}
+
+// HistogramBucket counts the elves carrying between From and To calories,
+// both included.
+type HistogramBucket struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Elves int `json:"elves"`
+}
+
+// CalorieStats describes the calories carried by all elves of a day 1 input.
+type CalorieStats struct {
+	Elves             int                `json:"elves"`
+	Items             int                `json:"items"`
+	TotalCalories     int                `json:"total_calories"`
+	ItemsPerElf       float64            `json:"items_per_elf"`
+	Mean              float64            `json:"mean"`
+	Median            float64            `json:"median"`
+	StandardDeviation float64            `json:"standard_deviation"`
+	Percentiles       []PercentileRecord `json:"percentiles"`
+	Histogram         []HistogramBucket  `json:"histogram"`
+	MostItems         ElfRecord          `json:"most_items"`
+	FewestItems       ElfRecord          `json:"fewest_items"`
+}
+
+// caloriePercentiles are taken by nearest rank, the 50th is left out since
+// the median is reported on its own, interpolated between the middle elves.
+var caloriePercentiles = []int{10, 25, 75, 90, 99}
+
+const histogramBuckets = 10
+
+func getCaloriesPercentile(sortedCalories []int, percentile int) int {
+	rank := (percentile*len(sortedCalories)+99)/100 - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sortedCalories[rank]
+}
+
+func getCaloriesHistogram(sortedCalories []int) []HistogramBucket {
+	lowest, highest := sortedCalories[0], sortedCalories[len(sortedCalories)-1]
+	width := (highest - lowest + histogramBuckets) / histogramBuckets
+
+	histogram := make([]HistogramBucket, 0, histogramBuckets)
+	for from := lowest; from <= highest; from += width {
+		histogram = append(histogram, HistogramBucket{From: from, To: from + width - 1})
+	}
+	for _, calories := range sortedCalories {
+		histogram[(calories-lowest)/width].Elves++
+	}
+	return histogram
+}
+
+// getCalorieStats streams the elves the same way as the solvers do, only the
+// totals are kept to find the median and percentiles.
+func getCalorieStats(lines *LineScanner) (CalorieStats, error) {
+	stats := CalorieStats{}
+	calories := []int{}
+	var mostItems, fewestItems ElfCalories
+	err := forEachElfsCalories(lines, func(elf ElfCalories) {
+		if stats.Elves == 0 || elf.items > mostItems.items {
+			mostItems = elf
+		}
+		if stats.Elves == 0 || elf.items < fewestItems.items {
+			fewestItems = elf
+		}
+		stats.Elves++
+		stats.Items += elf.items
+		stats.TotalCalories += elf.calories
+		calories = append(calories, elf.calories)
+	})
+	if err != nil {
+		return CalorieStats{}, err
+	}
+	if stats.Elves == 0 {
+		return CalorieStats{}, errors.New("no elves in the input")
+	}
+
+	stats.ItemsPerElf = float64(stats.Items) / float64(stats.Elves)
+	stats.Mean = float64(stats.TotalCalories) / float64(stats.Elves)
+	variance := 0.0
+	for _, elfsCalories := range calories {
+		variance += (float64(elfsCalories) - stats.Mean) * (float64(elfsCalories) - stats.Mean)
+	}
+	stats.StandardDeviation = math.Sqrt(variance / float64(stats.Elves))
+
+	sort.Ints(calories)
+	middle := len(calories) / 2
+	if len(calories)%2 == 0 {
+		stats.Median = float64(calories[middle-1]+calories[middle]) / 2
+	} else {
+		stats.Median = float64(calories[middle])
+	}
+	for _, percentile := range caloriePercentiles {
+		stats.Percentiles = append(stats.Percentiles, PercentileRecord{percentile, getCaloriesPercentile(calories, percentile)})
+	}
+	stats.Histogram = getCaloriesHistogram(calories)
+	stats.MostItems = newElfRecord(mostItems)
+	stats.FewestItems = newElfRecord(fewestItems)
+	return stats, nil
+}
+
+func writeCalorieStatsTable(writer io.Writer, stats CalorieStats) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "elves\t%d\n", stats.Elves)
+	fmt.Fprintf(table, "items\t%d\n", stats.Items)
+	fmt.Fprintf(table, "items per elf\t%.2f\n", stats.ItemsPerElf)
+	fmt.Fprintf(table, "total calories\t%d\n", stats.TotalCalories)
+	fmt.Fprintf(table, "mean\t%.2f\n", stats.Mean)
+	fmt.Fprintf(table, "median\t%.1f\n", stats.Median)
+	fmt.Fprintf(table, "standard deviation\t%.2f\n", stats.StandardDeviation)
+	for _, percentile := range stats.Percentiles {
+		fmt.Fprintf(table, "p%d\t%d\n", percentile.Percentile, percentile.Calories)
+	}
+	fmt.Fprintf(table, "most items\telf %d, %d items, %d calories\n", stats.MostItems.Elf, stats.MostItems.Items, stats.MostItems.Calories)
+	fmt.Fprintf(table, "fewest items\telf %d, %d items, %d calories\n", stats.FewestItems.Elf, stats.FewestItems.Items, stats.FewestItems.Calories)
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(writer, "\ncalories per elf:")
+	largestBucket := 0
+	for _, bucket := range stats.Histogram {
+		if bucket.Elves > largestBucket {
+			largestBucket = bucket.Elves
+		}
+	}
+	table = tabwriter.NewWriter(writer, 0, 0, 1, ' ', tabwriter.AlignRight)
+	for _, bucket := range stats.Histogram {
+		bar := strings.Repeat("#", (bucket.Elves*40+largestBucket-1)/largestBucket)
+		fmt.Fprintf(table, "%d\t-\t%d\t| %s %d\n", bucket.From, bucket.To, bar, bucket.Elves)
+	}
+	return table.Flush()
+}
+
+func statsCommand(args []string) {
+	flagSet := flag.NewFlagSet("stats", flag.ExitOnError)
+	formatString := flagSet.String("format", "text", "output format: text or json")
+	input := addInputFlag(flagSet)
+	flagSet.Usage = func() {
+		fmt.Fprintln(flagSet.Output(), "usage: stats [flags], describes the calories of the day 1 input")
+		flagSet.PrintDefaults()
+	}
+	flagSet.Parse(args)
+
+	format, err := parseOutputFormat(*formatString)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if format == CSVFormat {
+		log.Fatal("stats are written as text or json")
+	}
+
+	source := newInputSource(*input, 1)
+	reader, err := source.Open(1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer reader.Close()
+
+	stats, err := getCalorieStats(newLineScanner(reader, maxLineLength))
+	if err != nil {
+		log.Fatal(setParseErrorFile(err, source.Name(1)))
+	}
+
+	if format == JSONFormat {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(stats)
+	} else {
+		err = writeCalorieStatsTable(os.Stdout, stats)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// StrategyMapping is one reading of the second column of the strategy guide,
+// either as the hand to play or as the outcome to reach.
+type StrategyMapping struct {
+	Column  string   `json:"column"`
+	Mapping []string `json:"mapping"`
+	Score   int      `json:"score"`
+	Won     int      `json:"won"`
+}
+
+func (m StrategyMapping) String() string {
+	return fmt.Sprintf("%s as %s", strings.Join(m.Mapping, " "), m.Column)
+}
+
+// StrategyAnalysis scores every mapping of the second column. Likely is the
+// mapping the author most likely meant: a guide is written to win, so it is
+// the mapping that wins the most rounds, the higher score breaks a tie.
+type StrategyAnalysis struct {
+	Rounds   int               `json:"rounds"`
+	Mappings []StrategyMapping `json:"mappings"`
+	Likely   StrategyMapping   `json:"likely"`
+	Best     StrategyMapping   `json:"best"`
+	Worst    StrategyMapping   `json:"worst"`
+}
+
+// forEachPermutation visits the permutations of 0..n-1 in lexicographic
+// order, visit must not keep the slice.
+func forEachPermutation(n int, visit func(permutation []int)) {
+	permutation := make([]int, 0, n)
+	used := make([]bool, n)
+	var permute func()
+	permute = func() {
+		if len(permutation) == n {
+			visit(permutation)
+			return
+		}
+		for idx := 0; idx < n; idx++ {
+			if used[idx] {
+				continue
+			}
+			used[idx] = true
+			permutation = append(permutation, idx)
+			permute()
+			permutation = permutation[:len(permutation)-1]
+			used[idx] = false
+		}
+	}
+	permute()
+}
+
+// countStrategyGuideRounds streams the guide and counts every distinct row,
+// so each mapping is scored from the counts instead of the rows.
+func countStrategyGuideRounds(game *Game, lines *LineScanner) (map[StrategyGuideRow]int, int, error) {
+	responses := game.responseSymbols
+	for _, symbol := range []byte(expectedResultSymbols) {
+		if strings.IndexByte(responses, symbol) < 0 {
+			responses += string(symbol)
+		}
+	}
+
+	counts := map[StrategyGuideRow]int{}
+	rounds := 0
+	err := forEachStrategyGuideRow(lines, game.opponentSymbols, responses, func(row StrategyGuideRow) {
+		counts[row]++
+		rounds++
+	})
+	return counts, rounds, err
+}
+
+// scoreStrategyMapping plays every counted row, play returns your hand for a
+// row or false when the mapping has no meaning for its second column.
+func scoreStrategyMapping(game *Game, counts map[StrategyGuideRow]int, play func(row StrategyGuideRow, opponent Hand) (Hand, bool)) (int, int, bool) {
+	score, won := 0, 0
+	for row, count := range counts {
+		opponent := game.getOpponentHand(row.opponent)
+		you, ok := play(row, opponent)
+		if !ok {
+			return 0, 0, false
+		}
+		score += count * (game.getWinnerPoints(opponent, you) + game.getHandPoints(you))
+		if game.getResult(opponent, you) == Won {
+			won += count
+		}
+	}
+	return score, won, true
+}
+
+// analyzeStrategyGuide tries every bijection of the response symbols onto the
+// hands, and of X, Y and Z onto the outcomes.
+func analyzeStrategyGuide(game *Game, lines *LineScanner) (StrategyAnalysis, error) {
+	counts, rounds, err := countStrategyGuideRounds(game, lines)
+	if err != nil {
+		return StrategyAnalysis{}, err
+	}
+	analysis := StrategyAnalysis{Rounds: rounds}
+
+	forEachPermutation(len(game.hands), func(permutation []int) {
+		score, won, ok := scoreStrategyMapping(game, counts, func(row StrategyGuideRow, opponent Hand) (Hand, bool) {
+			symbol := strings.IndexByte(game.responseSymbols, row.response)
+			if symbol < 0 {
+				return 0, false
+			}
+			return Hand(permutation[symbol]), true
+		})
+		if !ok {
+			return
+		}
+		mapping := StrategyMapping{Column: "hands", Score: score, Won: won}
+		for symbol, hand := range permutation {
+			mapping.Mapping = append(mapping.Mapping, fmt.Sprintf("%c=%s", game.responseSymbols[symbol], game.getHandName(Hand(hand))))
+		}
+		analysis.Mappings = append(analysis.Mappings, mapping)
+	})
+
+	forEachPermutation(len(expectedResultSymbols), func(permutation []int) {
+		score, won, ok := scoreStrategyMapping(game, counts, func(row StrategyGuideRow, opponent Hand) (Hand, bool) {
+			symbol := strings.IndexByte(expectedResultSymbols, row.response)
+			if symbol < 0 {
+				return 0, false
+			}
+			return game.getYourHand(ExpectedResult(permutation[symbol]), opponent), true
+		})
+		if !ok {
+			return
+		}
+		mapping := StrategyMapping{Column: "outcomes", Score: score, Won: won}
+		for symbol, expectedResult := range permutation {
+			mapping.Mapping = append(mapping.Mapping, fmt.Sprintf("%c=%v", expectedResultSymbols[symbol], ExpectedResult(expectedResult)))
+		}
+		analysis.Mappings = append(analysis.Mappings, mapping)
+	})
+
+	for idx, mapping := range analysis.Mappings {
+		likely := analysis.Likely
+		if idx == 0 || mapping.Won > likely.Won || (mapping.Won == likely.Won && mapping.Score > likely.Score) {
+			analysis.Likely = mapping
+		}
+		if idx == 0 || mapping.Score > analysis.Best.Score {
+			analysis.Best = mapping
+		}
+		if idx == 0 || mapping.Score < analysis.Worst.Score {
+			analysis.Worst = mapping
+		}
+	}
+	return analysis, nil
+}
+
+func writeStrategyAnalysisTable(writer io.Writer, analysis StrategyAnalysis) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "mapping\tcolumn\tscore\twon")
+	for _, mapping := range analysis.Mappings {
+		fmt.Fprintf(table, "%s\t%s\t%d\t%d/%d\n", strings.Join(mapping.Mapping, " "), mapping.Column, mapping.Score, mapping.Won, analysis.Rounds)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(writer, "\nlikely: %v, wins %d of %d rounds\n", analysis.Likely, analysis.Likely.Won, analysis.Rounds)
+	fmt.Fprintf(writer, "best:   %v, %d points\n", analysis.Best, analysis.Best.Score)
+	fmt.Fprintf(writer, "worst:  %v, %d points\n", analysis.Worst, analysis.Worst.Score)
+	return nil
+}
+
+func strategyCommand(args []string) {
+	flagSet := flag.NewFlagSet("strategy", flag.ExitOnError)
+	formatString := flagSet.String("format", "text", "output format: text or json")
+	input := addInputFlag(flagSet)
+	addGameFlag(flagSet)
+	flagSet.Usage = func() {
+		fmt.Fprintln(flagSet.Output(), "usage: strategy [flags], scores every reading of the second column of the day 2 guide")
+		flagSet.PrintDefaults()
+	}
+	flagSet.Parse(args)
+
+	format, err := parseOutputFormat(*formatString)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if format == CSVFormat {
+		log.Fatal("the analysis is written as text or json")
+	}
+
+	source := newInputSource(*input, 1)
+	reader, err := source.Open(2)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer reader.Close()
+
+	analysis, err := analyzeStrategyGuide(day2Game, newLineScanner(reader, maxLineLength))
+	if err != nil {
+		log.Fatal(setParseErrorFile(err, source.Name(2)))
+	}
+
+	if format == JSONFormat {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(analysis)
+	} else {
+		err = writeStrategyAnalysisTable(os.Stdout, analysis)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestForEachPermutation(t *testing.T) {
+	seen := map[[4]int]bool{}
+	forEachPermutation(4, func(permutation []int) {
+		seen[[4]int(permutation)] = true
+	})
+	if len(seen) != 24 {
+		t.Errorf("visited %d distinct permutations, want 24", len(seen))
+	}
+}
+
+func TestAnalyzeStrategyGuide(t *testing.T) {
+	file, err := os.Open(filepath.Join("testdata", "day2.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	analysis, err := analyzeStrategyGuide(mustNewGame(gamePresets["rps"]), newLineScanner(file, maxLineLength))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Rounds != 3 || len(analysis.Mappings) != 12 {
+		t.Fatalf("got %d rounds and %d mappings, want 3 and 12", analysis.Rounds, len(analysis.Mappings))
+	}
+
+	// the readings of part 1 and part 2
+	scores := map[string]int{}
+	for _, mapping := range analysis.Mappings {
+		scores[mapping.String()] = mapping.Score
+	}
+	tests := map[string]int{
+		"X=Rock Y=Paper Z=Scissors as hands": 15,
+		"X=Lost Y=Draw Z=Won as outcomes":    12,
+	}
+	for mapping, want := range tests {
+		if got := scores[mapping]; got != want {
+			t.Errorf("%s scores %d, want %d", mapping, got, want)
+		}
+	}
+
+	// only the best mapping wins all three rounds
+	if got := analysis.Likely.String(); got != "X=Scissors Y=Paper Z=Rock as hands" || analysis.Likely.Won != 3 {
+		t.Errorf("likely is %s winning %d rounds", got, analysis.Likely.Won)
+	}
+	if got := analysis.Best.String(); got != "X=Scissors Y=Paper Z=Rock as hands" || analysis.Best.Score != 24 {
+		t.Errorf("best is %s with %d points", got, analysis.Best.Score)
+	}
+	if got := analysis.Worst.String(); got != "X=Rock Y=Scissors Z=Paper as hands" || analysis.Worst.Score != 6 {
+		t.Errorf("worst is %s with %d points", got, analysis.Worst.Score)
+	}
+	if analysis.Best.Won != 3 || analysis.Worst.Won != 0 {
+		t.Errorf("best wins %d and worst wins %d rounds, want 3 and 0", analysis.Best.Won, analysis.Worst.Won)
+	}
+}
+
+// TestAnalyzeStrategyGuideLikely has a guide where the best score wins fewer
+// rounds than the likely mapping.
+func TestAnalyzeStrategyGuideLikely(t *testing.T) {
+	guide := "C Z\nB Z\nB Y\nB Y\n"
+	analysis, err := analyzeStrategyGuide(mustNewGame(gamePresets["rps"]), newLineScanner(strings.NewReader(guide), maxLineLength))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := analysis.Likely.String(); got != "X=Paper Y=Scissors Z=Rock as hands" || analysis.Likely.Won != 3 || analysis.Likely.Score != 26 {
+		t.Errorf("likely is %s winning %d rounds with %d points", got, analysis.Likely.Won, analysis.Likely.Score)
+	}
+	if got := analysis.Best.String(); got != "X=Lost Y=Won Z=Draw as outcomes" || analysis.Best.Won != 2 || analysis.Best.Score != 29 {
+		t.Errorf("best is %s winning %d rounds with %d points", got, analysis.Best.Won, analysis.Best.Score)
+	}
+}
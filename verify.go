@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+)
+
+// AnswersFile maps day to part to the expected answer, as written by
+// Answer.String, for one set of inputs.
+type AnswersFile map[string]map[string]string
+
+func readAnswersFile(filename string) (AnswersFile, error) {
+	file, err := readFromFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := AnswersFile{}
+	if err := json.Unmarshal([]byte(file), &answers); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return answers, nil
+}
+
+func (a AnswersFile) getExpectedAnswer(key SolverKey) (string, bool) {
+	parts, ok := a[strconv.Itoa(key.day)]
+	if !ok {
+		return "", false
+	}
+	expected, ok := parts[strconv.Itoa(key.part)]
+	return expected, ok
+}
+
+type VerifyStatus int
+
+const (
+	PASS VerifyStatus = iota
+	FAIL
+	MISSING
+)
+
+func (v VerifyStatus) String() string {
+	return [...]string{"PASS", "FAIL", "MISSING"}[v]
+}
+
+func verifyAnswer(answers AnswersFile, key SolverKey, answer Answer, solveErr error) (VerifyStatus, string, string) {
+	actual := answer.String()
+	if solveErr != nil {
+		actual = "error: " + solveErr.Error()
+	}
+
+	expected, ok := answers.getExpectedAnswer(key)
+	if !ok {
+		return MISSING, expected, actual
+	}
+	if solveErr != nil || expected != actual {
+		return FAIL, expected, actual
+	}
+	return PASS, expected, actual
+}
+
+func verifyCommand(args []string) {
+	flagSet := flag.NewFlagSet("verify", flag.ExitOnError)
+	selection := addSelectionFlags(flagSet)
+	answersFileName := flagSet.String("answers", "answers.json", "file with the expected answers")
+	input := addInputFlag(flagSet)
+	flagSet.Parse(args)
+
+	if *selection.day == "" {
+		*selection.all = true
+	}
+	selected, err := selection.selectSolvers()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	answers, err := readAnswersFile(*answersFileName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source := newInputSource(*input, len(selected))
+	counts := make(map[VerifyStatus]int)
+	for _, key := range selected {
+		result := runSolverSafely(key, source)
+		answer := result.answer
+		status, expected, actual := verifyAnswer(answers, key, answer, result.err)
+		counts[status]++
+
+		switch status {
+		case PASS:
+			if answer.Kind() == ImageAnswer {
+				fmt.Printf("%-7s %v:\n%s\n", status, key, actual)
+			} else {
+				fmt.Printf("%-7s %v: %s\n", status, key, actual)
+			}
+		case FAIL:
+			fmt.Printf("%-7s %v:\n  expected: %q\n  actual:   %q\n", status, key, expected, actual)
+		case MISSING:
+			fmt.Printf("%-7s %v: actual %q\n", status, key, actual)
+		}
+	}
+
+	fmt.Printf("%d passed, %d failed, %d missing\n", counts[PASS], counts[FAIL], counts[MISSING])
+	if counts[FAIL] > 0 {
+		os.Exit(1)
+	}
+}
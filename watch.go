@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+type FileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+func getFileState(path string) FileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return FileState{}
+	}
+	return FileState{exists: true, modTime: info.ModTime(), size: info.Size()}
+}
+
+// getWatchedFiles returns the on-disk files read by the selected days, a
+// missing inputN.txt is still watched in case it gets created.
+func getWatchedFiles(keys []SolverKey, source InputSource) ([]string, error) {
+	if _, ok := source.(*ReaderInputSource); ok {
+		return nil, errors.New("can not watch piped input, use -input with a file")
+	}
+
+	files := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		name := source.Name(key.day)
+		if !seen[name] {
+			seen[name] = true
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+func clearScreen() {
+	fmt.Print("\033[H\033[2J")
+}
+
+// watchSolvers polls the input files and re-runs the selected days whenever
+// any of them changes, run is called once up front.
+func watchSolvers(keys []SolverKey, source InputSource, interval time.Duration, run func()) error {
+	files, err := getWatchedFiles(keys, source)
+	if err != nil {
+		return err
+	}
+
+	states := make(map[string]FileState)
+	for _, file := range files {
+		states[file] = getFileState(file)
+	}
+
+	clearScreen()
+	run()
+	for {
+		time.Sleep(interval)
+
+		changed := false
+		for _, file := range files {
+			state := getFileState(file)
+			if state != states[file] {
+				states[file] = state
+				changed = true
+			}
+		}
+		if changed {
+			clearScreen()
+			run()
+		}
+	}
+}